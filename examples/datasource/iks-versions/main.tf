@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    intelcloud = {
+      source  = "intel/intelcloud"
+      version = "0.0.19"
+    }
+  }
+}
+
+provider "intelcloud" {
+  region = "us-region-2"
+}
+
+data "intelcloud_iks_versions" "supported" {
+  filters = [
+    {
+      name   = "version"
+      values = ["1.30"]
+    }
+  ]
+}
+
+resource "intelcloud_iks_cluster" "cluster1" {
+  name               = "demo-iks"
+  kubernetes_version = data.intelcloud_iks_versions.supported.default
+}
+
+output "iks_versions" {
+  value = data.intelcloud_iks_versions.supported.versions
+}
@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    intelcloud = {
+      source  = "intel/intelcloud"
+      version = "0.0.19"
+    }
+  }
+}
+
+provider "intelcloud" {
+  region = "us-region-2"
+}
+
+data "intelcloud_regions" "all" {}
+
+output "current_region" {
+  value = data.intelcloud_regions.all.current
+}
+
+output "zones" {
+  value = { for r in data.intelcloud_regions.all.regions : r.name => r.zones }
+}
@@ -106,12 +106,25 @@ resource "intelcloud_iks_node_group" "ng1" {
 
 resource "intelcloud_iks_lb" "lb1" {
   cluster_uuid = intelcloud_iks_cluster.cluster1.id
-  load_balancers = [
-    {
-      name     = "${local.name}-lb-pub2"
+  load_balancers {
+    name   = "${local.name}-lb-pub2"
+    schema = "public"
+    listeners {
       port     = 80
-      vip_type = "public"
+      protocol = "TCP"
+      pool {
+        port                = 30080
+        monitor             = "tcp"
+        load_balancing_mode = "roundRobin"
+        node_group_id       = intelcloud_iks_node_group.ng1.id
+      }
+      security {
+        source_ips = ["any"]
+      }
     }
-  ]
+    security {
+      source_ips = ["any"]
+    }
+  }
   depends_on = [intelcloud_iks_node_group.ng1]
 }
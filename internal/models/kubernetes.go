@@ -86,6 +86,12 @@ var NodeGroupAttributes = map[string]attr.Type{
 	"userdata_url":  types.StringType,
 }
 
+type NodeGroupTaint struct {
+	Key    types.String `tfsdk:"key"`
+	Value  types.String `tfsdk:"value"`
+	Effect types.String `tfsdk:"effect"`
+}
+
 var VnetAttributes = map[string]attr.Type{
 	"availabilityzonename":     types.StringType,
 	"networkinterfacevnetname": types.StringType,
@@ -115,11 +121,14 @@ type VipsLoadBalancer struct {
 //}
 
 type IKSLoadBalancer struct {
-	ID        types.String                   `tfsdk:"id"`
-	Name      types.String                   `tfsdk:"name"`
-	Schema    types.String                   `tfsdk:"schema"`
-	Listeners []IKSLoadBalancerListenerModel `tfsdk:"listeners"`
-	Security  IKSLoadBalancerSecurityModel   `tfsdk:"security"`
+	ID             types.String                   `tfsdk:"id"`
+	Name           types.String                   `tfsdk:"name"`
+	Schema         types.String                   `tfsdk:"schema"`
+	Listeners      []IKSLoadBalancerListenerModel `tfsdk:"listeners"`
+	Security       IKSLoadBalancerSecurityModel   `tfsdk:"security"`
+	Vip            types.String                   `tfsdk:"vip"`
+	State          types.String                   `tfsdk:"state"`
+	ListenerStatus types.List                     `tfsdk:"listener_status"`
 }
 
 type IKSLoadBalancerListenerModel struct {
@@ -130,18 +139,13 @@ type IKSLoadBalancerListenerModel struct {
 }
 
 type IKSLoadBalancerPoolModel struct {
-	Port              types.Int64  `tfsdk:"port"`
-	Monitor           types.String `tfsdk:"monitor"`
-	LoadBalancingMode types.String `tfsdk:"load_balancing_mode"`
-	NodeGroupId       types.String `tfsdk:"node_group_id"`
-}
-
-type LoadBalancerPoolModel struct {
-	Port                types.Int64       `tfsdk:"port"`
-	Monitor             types.String      `tfsdk:"monitor"`
-	LoadBalancingMode   types.String      `tfsdk:"load_balancing_mode"`
-	InstanceSelectors   map[string]string `tfsdk:"instance_selectors"`
-	InstanceResourceIds []string          `tfsdk:"instance_resource_ids"`
+	Port                types.Int64  `tfsdk:"port"`
+	Monitor             types.String `tfsdk:"monitor"`
+	LoadBalancingMode   types.String `tfsdk:"load_balancing_mode"`
+	NodeGroupId         types.String `tfsdk:"node_group_id"`
+	NodeGroupName       types.String `tfsdk:"node_group_name"`
+	InstanceResourceIds types.List   `tfsdk:"instance_resource_ids"`
+	InstanceSelectors   types.Map    `tfsdk:"instance_selectors"`
 }
 
 type IKSLoadBalancerSecurityModel struct {
@@ -162,3 +166,8 @@ var IKSLoadLalancerAttributes = map[string]attr.Type{
 type KubeconfigModel struct {
 	Config types.String `tfsdk:"config"`
 }
+
+type IKSVersion struct {
+	Version types.String `tfsdk:"version"`
+	Runtime types.String `tfsdk:"runtime"`
+}
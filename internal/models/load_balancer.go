@@ -0,0 +1,49 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type LoadBalancerListenerModel struct {
+	Port      types.Int64           `tfsdk:"port"`
+	Protocol  types.String          `tfsdk:"protocol"`
+	Pool      LoadBalancerPoolModel `tfsdk:"pool"`
+	SourceIps types.List            `tfsdk:"source_ips"`
+}
+
+type LoadBalancerPoolModel struct {
+	Port                types.Int64  `tfsdk:"port"`
+	Monitor             types.String `tfsdk:"monitor"`
+	LoadBalancingMode   types.String `tfsdk:"load_balancing_mode"`
+	InstanceSelectors   types.Map    `tfsdk:"instance_selectors"`
+	InstanceResourceIds types.List   `tfsdk:"instance_resource_ids"`
+}
+
+type LoadBalancerListenerStatus struct {
+	Port        types.Int64              `tfsdk:"port"`
+	State       types.String             `tfsdk:"state"`
+	Message     types.String             `tfsdk:"message"`
+	VipID       types.Int64              `tfsdk:"vip_id"`
+	PoolID      types.Int64              `tfsdk:"pool_id"`
+	PoolMembers []LoadBalancerPoolMember `tfsdk:"pool_members"`
+}
+
+type LoadBalancerPoolMember struct {
+	InstanceRef types.String `tfsdk:"instance_ref"`
+	IP          types.String `tfsdk:"ip"`
+}
+
+var LoadBalancerPoolMemberAttributes = map[string]attr.Type{
+	"instance_ref": types.StringType,
+	"ip":           types.StringType,
+}
+
+var LoadBalancerListenerStatusAttributes = map[string]attr.Type{
+	"port":         types.Int64Type,
+	"state":        types.StringType,
+	"message":      types.StringType,
+	"vip_id":       types.Int64Type,
+	"pool_id":      types.Int64Type,
+	"pool_members": types.ListType{ElemType: types.ObjectType{AttrTypes: LoadBalancerPoolMemberAttributes}},
+}
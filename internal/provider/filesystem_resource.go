@@ -8,6 +8,7 @@ import (
 
 	"terraform-provider-intelcloud/internal/models"
 	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -31,12 +32,14 @@ var (
 type filesystemResourceModel struct {
 	ID               types.String           `tfsdk:"id"`
 	Cloudaccount     types.String           `tfsdk:"cloudaccount"`
+	Region           types.String           `tfsdk:"region"`
 	Name             types.String           `tfsdk:"name"`
 	AvailabilityZone types.String           `tfsdk:"availability_zone"`
 	Spec             *models.FilesystemSpec `tfsdk:"spec"`
 	Status           types.String           `tfsdk:"status"`
 	ClusterInfo      types.Object           `tfsdk:"cluster_info"`
 	AccessInfo       types.Object           `tfsdk:"access_info"`
+	AdoptExisting    types.Bool             `tfsdk:"adopt_existing"`
 	Timeouts         *timeoutsModel         `tfsdk:"timeouts"`
 }
 
@@ -85,9 +88,12 @@ func (r *filesystemResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			"name": schema.StringAttribute{
 				Required: true,
 			},
-			"cloudaccount": schema.StringAttribute{
-				Computed: true,
+			"adopt_existing": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Adopt an existing filesystem with the same name instead of creating a new one.",
 			},
+			"cloudaccount": cloudaccountAttribute(),
+			"region":       regionAttribute(),
 			"availability_zone": schema.StringAttribute{
 				Computed: true,
 			},
@@ -170,45 +176,73 @@ func (r *filesystemResource) Create(ctx context.Context, req resource.CreateRequ
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
-	inArg := itacservices.FilesystemCreateRequest{
-		Metadata: struct {
-			Name string "json:\"name\""
-		}{
-			Name: plan.Name.ValueString(),
-		},
-		Spec: struct {
-			Request struct {
-				Size string "json:\"storage\""
-			} "json:\"request\""
-			StorageClass     string "json:\"storageClass\""
-			AccessMode       string "json:\"accessModes\""
-			FilesystemType   string "json:\"filesystemType\""
-			InstanceType     string "json:\"instanceType\""
-			Encrypted        bool   "json:\"Encrypted\""
-			AvailabilityZone string "json:\"availabilityZone\""
-		}{
-			Request: struct {
-				Size string "json:\"storage\""
-			}{
-				Size: fmt.Sprintf("%dTB", plan.Spec.Size.ValueInt64()),
-			},
-			FilesystemType:   "ComputeGeneral",
-			InstanceType:     "storage-file", // hard-coded for now
-			AvailabilityZone: fmt.Sprintf("%sa", *r.client.Region),
-			StorageClass:     "GeneralPurpose",
-			AccessMode:       plan.Spec.AccessMode.ValueString(),
-			Encrypted:        plan.Spec.Encrypted.ValueBool(),
-		},
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	tflog.Info(ctx, "making a call to IDC Service for create filesystem")
-	fsResp, err := r.client.CreateFilesystem(ctx, &inArg)
+	plan.Region = types.StringValue(*client.Region)
+
+	fsResp, err := adoptExisting(ctx, plan.AdoptExisting, plan.Name.ValueString(), client.GetFilesystemByName)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating order",
-			"Could not create order, unexpected error: "+err.Error(),
+			"Could not look up existing filesystem "+plan.Name.ValueString()+": "+err.Error(),
 		)
 		return
 	}
+	if fsResp != nil {
+		tflog.Info(ctx, "adopting existing filesystem", map[string]any{"ID": fsResp.Metadata.ResourceId})
+	} else {
+		availabilityZone, err := client.DefaultAvailabilityZone()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating order",
+				"Could not create order, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		inArg := itacservices.FilesystemCreateRequest{
+			Metadata: struct {
+				Name string "json:\"name\""
+			}{
+				Name: plan.Name.ValueString(),
+			},
+			Spec: struct {
+				Request struct {
+					Size string "json:\"storage\""
+				} "json:\"request\""
+				StorageClass     string "json:\"storageClass\""
+				AccessMode       string "json:\"accessModes\""
+				FilesystemType   string "json:\"filesystemType\""
+				InstanceType     string "json:\"instanceType\""
+				Encrypted        bool   "json:\"Encrypted\""
+				AvailabilityZone string "json:\"availabilityZone\""
+			}{
+				Request: struct {
+					Size string "json:\"storage\""
+				}{
+					Size: fmt.Sprintf("%dTB", plan.Spec.Size.ValueInt64()),
+				},
+				FilesystemType:   "ComputeGeneral",
+				InstanceType:     "storage-file", // hard-coded for now
+				AvailabilityZone: availabilityZone,
+				StorageClass:     "GeneralPurpose",
+				AccessMode:       plan.Spec.AccessMode.ValueString(),
+				Encrypted:        plan.Spec.Encrypted.ValueBool(),
+			},
+		}
+		tflog.Info(ctx, "making a call to IDC Service for create filesystem")
+		fsResp, err = client.CreateFilesystem(ctx, &inArg)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating order",
+				"Could not create order, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
 
 	// Map response body to schema and populate Computed attribute values
 	plan.AvailabilityZone = types.StringValue(fsResp.Spec.AvailabilityZone)
@@ -273,8 +307,19 @@ func (r *filesystemResource) Read(ctx context.Context, req resource.ReadRequest,
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
 
+	client, diags := regionalClient(ctx, r.client, orig.Region, orig.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get refreshed order value from IDC Service
-	filesystem, err := r.client.GetFilesystemByResourceId(ctx, orig.ID.ValueString())
+	filesystem, err := client.GetFilesystemByResourceId(ctx, orig.ID.ValueString())
+	if common.IsNotFound(err) {
+		tflog.Warn(ctx, "filesystem not found, removing from state", map[string]any{"id": orig.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading IDC Filesystem resource",
@@ -291,6 +336,9 @@ func (r *filesystemResource) Read(ctx context.Context, req resource.ReadRequest,
 		)
 		return
 	}
+	state.AdoptExisting = orig.AdoptExisting
+	state.Timeouts = orig.Timeouts
+	state.Region = types.StringValue(*client.Region)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, state)
@@ -331,6 +379,13 @@ func (r *filesystemResource) Update(ctx context.Context, req resource.UpdateRequ
 		ctx, cancel := context.WithTimeout(ctx, updateTimeout)
 		defer cancel()
 
+		client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.Region = types.StringValue(*client.Region)
+
 		inArg := itacservices.FilesystemUpdateRequest{
 			Metadata: struct {
 				Name string "json:\"name\""
@@ -353,7 +408,7 @@ func (r *filesystemResource) Update(ctx context.Context, req resource.UpdateRequ
 		}
 
 		tflog.Info(ctx, "making a call to IDC Service for update filesystem", map[string]any{"Payload": inArg})
-		err = r.client.UpdateFilesystem(ctx, &inArg)
+		err = client.UpdateFilesystem(ctx, &inArg)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error creating order",
@@ -363,7 +418,7 @@ func (r *filesystemResource) Update(ctx context.Context, req resource.UpdateRequ
 		}
 
 		// Get refreshed order value from IDC Service
-		filesystem, err := r.client.GetFilesystemByResourceId(ctx, state.ID.ValueString())
+		filesystem, err := client.GetFilesystemByResourceId(ctx, state.ID.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Reading IDC Filesystem resource",
@@ -381,6 +436,9 @@ func (r *filesystemResource) Update(ctx context.Context, req resource.UpdateRequ
 			return
 		}
 		currState.Spec.Size = plan.Spec.Size
+		currState.AdoptExisting = plan.AdoptExisting
+		currState.Timeouts = plan.Timeouts
+		currState.Region = plan.Region
 
 		// Set refreshed state
 		diags = resp.State.Set(ctx, currState)
@@ -393,8 +451,32 @@ func (r *filesystemResource) Update(ctx context.Context, req resource.UpdateRequ
 }
 
 func (r *filesystemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	scope, diags := resolveImportScope(ctx, r.client, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Accept either the resource ID or the name of the filesystem
+	id, err := resolveImportIDOrName(ctx, "filesystem", scope.id,
+		func(ctx context.Context, id string) error {
+			_, err := scope.client.GetFilesystemByResourceId(ctx, id)
+			return err
+		},
+		func(ctx context.Context, name string) (string, error) {
+			fs, err := scope.client.GetFilesystemByName(ctx, name)
+			if err != nil {
+				return "", err
+			}
+			return fs.Metadata.ResourceId, nil
+		})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import IDC Filesystem resource", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(scope.setState(ctx, &resp.State, path.Root("cloudaccount"))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -415,8 +497,14 @@ func (r *filesystemResource) Delete(ctx context.Context, req resource.DeleteRequ
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
 
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the order from IDC Services
-	err = r.client.DeleteFilesystemByResourceId(ctx, state.ID.ValueString())
+	err = client.DeleteFilesystemByResourceId(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting IDC Instance resource",
@@ -424,6 +512,12 @@ func (r *filesystemResource) Delete(ctx context.Context, req resource.DeleteRequ
 		)
 		return
 	}
+	if err := client.WaitForFilesystemDeleted(ctx, state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting IDC Filesystem resource",
+			"Could not delete IDC Filesystem resource ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+	}
 }
 
 func mapFilesystemStatus(fsStatus string) string {
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-intelcloud/pkg/fakeitac"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testAccFilesystemConfig = `
+resource "intelcloud_filesystem" "test" {
+  name = "tf-acc-fs"
+  spec = {
+    size_in_tb = %d
+  }
+}
+`
+
+func TestAccFilesystemResource(t *testing.T) {
+	server, providerConfig := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(server, "filesystems"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(testAccFilesystemConfig, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("intelcloud_filesystem.test", "id"),
+					resource.TestCheckResourceAttr("intelcloud_filesystem.test", "status", "ready"),
+					resource.TestCheckResourceAttr("intelcloud_filesystem.test", "spec.size_in_tb", "1"),
+				),
+			},
+			{
+				Config: providerConfig + fmt.Sprintf(testAccFilesystemConfig, 2),
+				Check:  resource.TestCheckResourceAttr("intelcloud_filesystem.test", "spec.size_in_tb", "2"),
+			},
+		},
+	})
+}
+
+func TestAccFilesystemResource_ProvisioningFails(t *testing.T) {
+	server, providerConfig := testAccFakeServer(t)
+	server.InjectFault(fakeitac.Fault{Method: "POST", Path: "/filesystems", FailProvisioning: true, Message: "no capacity in zone"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + fmt.Sprintf(testAccFilesystemConfig, 1),
+				ExpectError: regexp.MustCompile(`not\s+ready,\s+last\s+phase\s+"FSFailed":\s+no\s+capacity\s+in\s+zone`),
+			},
+		},
+	})
+}
+
+func TestAccFilesystemResource_APIError(t *testing.T) {
+	server, providerConfig := testAccFakeServer(t)
+	server.InjectFault(fakeitac.Fault{Method: "POST", Path: "/filesystems", Status: 503, Message: "storage backend unavailable"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + fmt.Sprintf(testAccFilesystemConfig, 1),
+				ExpectError: regexp.MustCompile(`storage\s+backend\s+unavailable\s+\(request\s+ID:\s+fake-request-1\)`),
+			},
+		},
+	})
+}
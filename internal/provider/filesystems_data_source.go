@@ -12,7 +12,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -32,6 +31,7 @@ var (
 
 // storagesDataSourceModel maps the data source schema data.
 type filesystemsDataSourceModel struct {
+	Name        types.String             `tfsdk:"name"`
 	Filesystems []models.FilesystemModel `tfsdk:"filesystems"`
 }
 
@@ -61,6 +61,10 @@ func (d *filesystemsDataSource) Metadata(_ context.Context, req datasource.Metad
 func (d *filesystemsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the filesystem with this name.",
+			},
 			"filesystems": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -129,19 +133,37 @@ func (d *filesystemsDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 func (d *filesystemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
 	var state filesystemsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state.Filesystems = []models.FilesystemModel{}
 
-	var diags diag.Diagnostics
-	fsList, err := d.client.GetFilesystems(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read IDC Filesystems",
-			err.Error(),
-		)
-		return
+	filesystems := []itacservices.Filesystem{}
+	if !state.Name.IsNull() {
+		fs, err := d.client.GetFilesystemByName(ctx, state.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read IDC Filesystem "+state.Name.ValueString(),
+				err.Error(),
+			)
+			return
+		}
+		filesystems = append(filesystems, *fs)
+	} else {
+		fsList, err := d.client.GetFilesystems(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read IDC Filesystems",
+				err.Error(),
+			)
+			return
+		}
+		filesystems = fsList.FilesystemList
 	}
 
-	for _, fs := range fsList.FilesystemList {
+	for _, fs := range filesystems {
 		sizeStr := strings.Split(fs.Spec.Request.Size, "GB")[0]
 		size, _ := strconv.ParseInt(sizeStr, 10, 64)
 		fsModel := models.FilesystemModel{
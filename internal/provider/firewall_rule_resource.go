@@ -0,0 +1,492 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &firewallRuleResource{}
+	_ resource.ResourceWithConfigure      = &firewallRuleResource{}
+	_ resource.ResourceWithImportState    = &firewallRuleResource{}
+	_ resource.ResourceWithValidateConfig = &firewallRuleResource{}
+)
+
+var firewallRuleProtocols = []string{"TCP", "UDP"}
+
+// firewallRuleResourceModel maps the resource schema data.
+type firewallRuleResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	Cloudaccount types.String   `tfsdk:"cloudaccount"`
+	Region       types.String   `tfsdk:"region"`
+	Name         types.String   `tfsdk:"name"`
+	Vnet         types.String   `tfsdk:"vnet"`
+	Vip          types.String   `tfsdk:"vip"`
+	Protocol     types.String   `tfsdk:"protocol"`
+	FromPort     types.Int64    `tfsdk:"from_port"`
+	ToPort       types.Int64    `tfsdk:"to_port"`
+	SourceCidrs  types.List     `tfsdk:"source_cidrs"`
+	State        types.String   `tfsdk:"state"`
+	Timeouts     *timeoutsModel `tfsdk:"timeouts"`
+}
+
+// NewFirewallRuleResource is a helper function to simplify the provider implementation.
+func NewFirewallRuleResource() resource.Resource {
+	return &firewallRuleResource{}
+}
+
+// firewallRuleResource is the resource implementation.
+type firewallRuleResource struct {
+	client *itacservices.IDCServicesClient
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *firewallRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*itacservices.IDCServicesClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *itacservices.IDCServicesClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *firewallRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_rule"
+}
+
+// Schema defines the schema for the resource.
+func (r *firewallRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ingress firewall rule for a vnet or a load balancer VIP.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cloudaccount": cloudaccountAttribute(),
+			"region":       regionAttribute(),
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the firewall rule. Changing this forces a new rule.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vnet": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the vnet the rule applies to. Exactly one of vnet or vip must be set. Changing this forces a new rule.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vip": schema.StringAttribute{
+				Optional:    true,
+				Description: "Load balancer VIP the rule applies to. Exactly one of vnet or vip must be set. Changing this forces a new rule.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"protocol": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("TCP"),
+				Description: "Protocol of the allowed traffic, TCP or UDP.",
+			},
+			"from_port": schema.Int64Attribute{
+				Required:    true,
+				Description: "First port of the allowed port range.",
+			},
+			"to_port": schema.Int64Attribute{
+				Required:    true,
+				Description: "Last port of the allowed port range.",
+			},
+			"source_cidrs": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Source CIDRs allowed by the rule, e.g. `10.0.0.0/8`. Use `0.0.0.0/0` to allow all.",
+			},
+			"state": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"resource_timeout": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Timeout for resource operation, supports 1s, 2m, 3h etc.",
+						Default:     stringdefault.StaticString(FirewallRuleResourceTimeout),
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the rule target, protocol, port range and source CIDRs.
+func (r *firewallRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config firewallRuleResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Vnet.IsUnknown() && !config.Vip.IsUnknown() && config.Vnet.IsNull() == config.Vip.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("vnet"), "Invalid firewall rule target",
+			"Exactly one of vnet or vip must be set")
+	}
+	if !config.Vip.IsNull() && !config.Vip.IsUnknown() && net.ParseIP(config.Vip.ValueString()) == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("vip"), "Invalid firewall rule VIP",
+			fmt.Sprintf("%q is not an IP address", config.Vip.ValueString()))
+	}
+
+	if !config.Protocol.IsNull() && !config.Protocol.IsUnknown() && !slices.Contains(firewallRuleProtocols, config.Protocol.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("protocol"), "Invalid firewall rule protocol",
+			fmt.Sprintf("protocol must be one of %v, got %q", firewallRuleProtocols, config.Protocol.ValueString()))
+	}
+
+	validateFirewallRulePort(path.Root("from_port"), config.FromPort, &resp.Diagnostics)
+	validateFirewallRulePort(path.Root("to_port"), config.ToPort, &resp.Diagnostics)
+	if !config.FromPort.IsNull() && !config.FromPort.IsUnknown() && !config.ToPort.IsNull() && !config.ToPort.IsUnknown() &&
+		config.FromPort.ValueInt64() > config.ToPort.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(path.Root("to_port"), "Invalid firewall rule port range",
+			fmt.Sprintf("to_port %d must not be lower than from_port %d", config.ToPort.ValueInt64(), config.FromPort.ValueInt64()))
+	}
+
+	if config.SourceCidrs.IsNull() || config.SourceCidrs.IsUnknown() {
+		return
+	}
+	for i, v := range config.SourceCidrs.Elements() {
+		cidr, ok := v.(types.String)
+		if !ok || cidr.IsUnknown() || cidr.IsNull() {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(cidr.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("source_cidrs").AtListIndex(i), "Invalid source CIDR",
+				fmt.Sprintf("%q is not a CIDR: %v", cidr.ValueString(), err))
+		}
+	}
+}
+
+func validateFirewallRulePort(p path.Path, port types.Int64, diags *diag.Diagnostics) {
+	if port.IsNull() || port.IsUnknown() {
+		return
+	}
+	if port.ValueInt64() < 1 || port.ValueInt64() > 65535 {
+		diags.AddAttributeError(p, "Invalid firewall rule port",
+			fmt.Sprintf("port must be between 1 and 65535, got %d", port.ValueInt64()))
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *firewallRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan firewallRuleResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// use timeouts if requested by the user
+	createTimeout, err := plan.Timeouts.GetTimeouts(FirewallRuleResourceName)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", "Could not parse create timeout: "+err.Error())
+	}
+	// Use the timeout context
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Region = types.StringValue(*client.Region)
+
+	spec, diags := firewallRuleSpecFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inArg := itacservices.FirewallRuleCreateRequest{
+		Spec: *spec,
+	}
+	inArg.Metadata.Name = plan.Name.ValueString()
+
+	tflog.Info(ctx, "making a call to IDC Service for create firewall rule")
+	rule, err := client.CreateFirewallRule(ctx, &inArg)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating firewall rule",
+			"Could not create firewall rule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	currState := refreshFirewallRuleResourceModel(rule)
+	currState.Timeouts = plan.Timeouts
+	currState.Region = plan.Region
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, currState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *firewallRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var orig firewallRuleResourceModel
+
+	diags := req.State.Get(ctx, &orig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// use timeouts if requested by the user
+	readTimeout, err := orig.Timeouts.GetTimeouts(FirewallRuleResourceName)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", "Could not parse read timeout: "+err.Error())
+	}
+	// Use the timeout context
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	client, diags := regionalClient(ctx, r.client, orig.Region, orig.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed value from IDC Service
+	rule, err := client.GetFirewallRuleByID(ctx, orig.ID.ValueString())
+	if common.IsNotFound(err) {
+		tflog.Warn(ctx, "firewall rule not found, removing from state", map[string]any{"id": orig.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading firewall rule resource",
+			"Could not read firewall rule resource ID "+orig.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state := refreshFirewallRuleResourceModel(rule)
+	state.Timeouts = orig.Timeouts
+	state.Region = types.StringValue(*client.Region)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *firewallRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state firewallRuleResourceModel
+
+	// Retrieve the desired configuration from the plan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// use timeouts if requested by the user
+	updateTimeout, err := plan.Timeouts.GetTimeouts(FirewallRuleResourceName)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", "Could not parse update timeout: "+err.Error())
+	}
+	// Use the timeout context
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Region = types.StringValue(*client.Region)
+
+	spec, diags := firewallRuleSpecFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "making a call to IDC Service for update firewall rule", map[string]any{"ID": state.ID.ValueString()})
+	rule, err := client.UpdateFirewallRule(ctx, state.ID.ValueString(), &itacservices.FirewallRuleUpdateRequest{Spec: *spec})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating firewall rule",
+			"Could not update firewall rule resource ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	currState := refreshFirewallRuleResourceModel(rule)
+	currState.Timeouts = plan.Timeouts
+	currState.Region = plan.Region
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, currState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *firewallRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Get current state
+	var state firewallRuleResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, err := state.Timeouts.GetTimeouts(FirewallRuleResourceName)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", "Could not parse delete timeout: "+err.Error())
+	}
+	// Use the timeout context
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = client.DeleteFirewallRule(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting firewall rule resource",
+			"Could not delete firewall rule resource ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if err := client.WaitForFirewallRuleDeleted(ctx, state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting firewall rule resource",
+			"Could not delete firewall rule resource ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+func (r *firewallRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scope, diags := resolveImportScope(ctx, r.client, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Accept either the resource ID or the name of the firewall rule
+	items, err := scope.client.GetFirewallRules(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to import firewall rule resource",
+			"Could not list firewall rules: "+err.Error(),
+		)
+		return
+	}
+
+	candidates := []importCandidate{}
+	for _, item := range items.FirewallRules {
+		candidates = append(candidates, importCandidate{ID: item.Metadata.ResourceID, Name: item.Metadata.Name})
+	}
+	id, err := resolveImportID("firewall rule", scope.id, candidates)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import firewall rule resource", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(scope.setState(ctx, &resp.State, path.Root("cloudaccount"))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func firewallRuleSpecFromModel(ctx context.Context, m *firewallRuleResourceModel) (*itacservices.FirewallRuleSpec, diag.Diagnostics) {
+	spec := &itacservices.FirewallRuleSpec{
+		VNet:          m.Vnet.ValueString(),
+		DestinationIP: m.Vip.ValueString(),
+		Protocol:      m.Protocol.ValueString(),
+		PortStart:     int32(m.FromPort.ValueInt64()),
+		PortEnd:       int32(m.ToPort.ValueInt64()),
+	}
+	diags := m.SourceCidrs.ElementsAs(ctx, &spec.SourceIPs, false)
+	return spec, diags
+}
+
+func refreshFirewallRuleResourceModel(rule *itacservices.FirewallRule) *firewallRuleResourceModel {
+	state := &firewallRuleResourceModel{
+		ID:           types.StringValue(rule.Metadata.ResourceID),
+		Cloudaccount: types.StringValue(rule.Metadata.CloudAccountID),
+		Name:         types.StringValue(rule.Metadata.Name),
+		Vnet:         types.StringNull(),
+		Vip:          types.StringNull(),
+		Protocol:     types.StringValue(rule.Spec.Protocol),
+		FromPort:     types.Int64Value(int64(rule.Spec.PortStart)),
+		ToPort:       types.Int64Value(int64(rule.Spec.PortEnd)),
+		SourceCidrs:  stringListValue(rule.Spec.SourceIPs),
+		State:        types.StringValue(rule.Status.State),
+	}
+	if rule.Spec.VNet != "" {
+		state.Vnet = types.StringValue(rule.Spec.VNet)
+	}
+	if rule.Spec.DestinationIP != "" {
+		state.Vip = types.StringValue(rule.Spec.DestinationIP)
+	}
+	return state
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"terraform-provider-intelcloud/internal/models"
 	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -29,6 +30,7 @@ var (
 type iksClusterResourceModel struct {
 	ID               types.String `tfsdk:"id"`
 	Cloudaccount     types.String `tfsdk:"cloudaccount"`
+	Region           types.String `tfsdk:"region"`
 	Name             types.String `tfsdk:"name"`
 	K8sversion       types.String `tfsdk:"kubernetes_version"`
 	ClusterStatus    types.String `tfsdk:"cluster_status"`
@@ -85,9 +87,8 @@ func (r *iksClusterResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
-			"cloudaccount": schema.StringAttribute{
-				Computed: true,
-			},
+			"cloudaccount": cloudaccountAttribute(),
+			"region":       regionAttribute(),
 			"kubernetes_version": schema.StringAttribute{
 				Required: true,
 			},
@@ -160,13 +161,20 @@ func (r *iksClusterResource) Create(ctx context.Context, req resource.CreateRequ
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Region = types.StringValue(*client.Region)
+
 	inArg := itacservices.IKSCreateRequest{
 		Name:         plan.Name.ValueString(),
 		K8sVersion:   plan.K8sversion.ValueString(),
 		InstanceType: "iks-cluster",
-		RuntimeName:  "Containerd",
+		RuntimeName:  itacservices.DefaultIKSRuntime,
 	}
-	iksClusterResp, cloudaccount, err := r.client.CreateIKSCluster(ctx, &inArg, false)
+	iksClusterResp, cloudaccount, err := client.CreateIKSCluster(ctx, &inArg, false)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating order",
@@ -202,7 +210,7 @@ func (r *iksClusterResource) Create(ctx context.Context, req resource.CreateRequ
 			Size:   fmt.Sprintf("%sTB", strconv.FormatInt(plan.Storage.Size.ValueInt64(), 10)),
 		}
 
-		storageResp, _, err := r.client.CreateIKSStorage(ctx, &inArg, plan.ID.ValueString())
+		storageResp, _, err := client.CreateIKSStorage(ctx, &inArg, plan.ID.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error creating iks file storage",
@@ -256,7 +264,19 @@ func (r *iksClusterResource) Read(ctx context.Context, req resource.ReadRequest,
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
 
-	iksClusterResp, cloudaccount, err := r.client.GetIKSClusterByClusterUUID(ctx, state.ID.ValueString())
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Region = types.StringValue(*client.Region)
+
+	iksClusterResp, cloudaccount, err := client.GetIKSClusterByClusterUUID(ctx, state.ID.ValueString())
+	if common.IsNotFound(err) {
+		tflog.Warn(ctx, "iks cluster not found, removing from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading state",
@@ -267,6 +287,8 @@ func (r *iksClusterResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	// Map response body to schema and populate Computed attribute values
 	state.ID = types.StringValue(iksClusterResp.ResourceId)
+	state.Name = types.StringValue(iksClusterResp.Name)
+	state.UpgardeAvailable = types.BoolValue(iksClusterResp.UpgradeAvailable)
 	state.ClusterStatus = types.StringValue(iksClusterResp.ClusterState)
 	state.K8sversion = types.StringValue(iksClusterResp.K8sVersion)
 	if cloudaccount != nil {
@@ -373,6 +395,13 @@ func (r *iksClusterResource) Update(ctx context.Context, req resource.UpdateRequ
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
 
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Region = types.StringValue(*client.Region)
+
 	if !plan.K8sversion.Equal(state.K8sversion) {
 		tflog.Info(ctx, "Detected change in iks cluster spec for k8s version, updating cluster",
 			map[string]any{"current version ": state.K8sversion.ValueString(), "new version": plan.K8sversion.ValueString()})
@@ -381,7 +410,7 @@ func (r *iksClusterResource) Update(ctx context.Context, req resource.UpdateRequ
 			ClusterId:  state.ID.ValueString(),
 			K8sVersion: plan.K8sversion.ValueString(),
 		}
-		err := r.client.UpgradeCluster(ctx, &inArg)
+		err := client.UpgradeCluster(ctx, &inArg)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error creating order",
@@ -392,7 +421,7 @@ func (r *iksClusterResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	// Get refreshed order value from IDC Service irrespective of whether upgrade was done or skipped
-	cluster, cloudaccount, err := r.client.GetIKSClusterByClusterUUID(ctx, state.ID.ValueString())
+	cluster, cloudaccount, err := client.GetIKSClusterByClusterUUID(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading IKS Cluster resource",
@@ -411,6 +440,7 @@ func (r *iksClusterResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 	// set timeout again for consistency
 	currState.Timeouts = plan.Timeouts
+	currState.Region = plan.Region
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, currState)
@@ -422,8 +452,21 @@ func (r *iksClusterResource) Update(ctx context.Context, req resource.UpdateRequ
 }
 
 func (r *iksClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	scope, diags := resolveImportScope(ctx, r.client, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Accept either the cluster UUID or the name of the cluster
+	clusterUUID, err := resolveIKSClusterImportID(ctx, scope.client, scope.id)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import IKS cluster", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(scope.setState(ctx, &resp.State, path.Root("cloudaccount"))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), clusterUUID)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -445,8 +488,14 @@ func (r *iksClusterResource) Delete(ctx context.Context, req resource.DeleteRequ
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
 
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the order from IDC Services
-	err = r.client.DeleteIKSCluster(ctx, state.ID.ValueString())
+	err = client.DeleteIKSCluster(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting IDC IKS Cluster resource",
@@ -454,6 +503,12 @@ func (r *iksClusterResource) Delete(ctx context.Context, req resource.DeleteRequ
 		)
 		return
 	}
+	if err := client.WaitForIKSClusterDeleted(ctx, state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting IDC IKS Cluster resource",
+			"Could not delete IDC IKS Cluster ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+	}
 }
 
 func refreshIKSCLusterResourceModel(ctx context.Context, cluster *itacservices.IKSCluster, cloudaccount *string) (*iksClusterResourceModel, error) {
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIKSClusterResource(t *testing.T) {
+	server, providerConfig := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(server, "iks/clusters"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "intelcloud_iks_cluster" "test" {
+  name               = "tf-acc-iks"
+  kubernetes_version = "1.28"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("intelcloud_iks_cluster.test", "id"),
+					resource.TestCheckResourceAttr("intelcloud_iks_cluster.test", "cluster_status", "Active"),
+					resource.TestCheckResourceAttr("intelcloud_iks_cluster.test", "cloudaccount", server.CloudAccount),
+				),
+			},
+			{
+				ResourceName:            "intelcloud_iks_cluster.test",
+				ImportState:             true,
+				ImportStateId:           "tf-acc-iks",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
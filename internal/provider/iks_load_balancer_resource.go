@@ -3,30 +3,39 @@ package provider
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"terraform-provider-intelcloud/internal/models"
 	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &iksLBResource{}
-	_ resource.ResourceWithConfigure   = &iksLBResource{}
-	_ resource.ResourceWithImportState = &iksLBResource{}
+	_ resource.Resource                   = &iksLBResource{}
+	_ resource.ResourceWithConfigure      = &iksLBResource{}
+	_ resource.ResourceWithImportState    = &iksLBResource{}
+	_ resource.ResourceWithValidateConfig = &iksLBResource{}
 )
 
 // orderIKSNodeGroupModel maps the resource schema data.
 type iksLoadBalancerResourceModel struct {
-	ClusterUUID  types.String           `tfsdk:"cluster_uuid"`
-	LoadBalancer models.IKSLoadBalancer `tfsdk:"load_balancers"`
-	Timeouts     *timeoutsModel         `tfsdk:"timeouts"`
+	ClusterUUID   types.String             `tfsdk:"cluster_uuid"`
+	Cloudaccount  types.String             `tfsdk:"cloudaccount"`
+	Region        types.String             `tfsdk:"region"`
+	LoadBalancers []models.IKSLoadBalancer `tfsdk:"load_balancers"`
+	Timeouts      *timeoutsModel           `tfsdk:"timeouts"`
 }
 
 // NewIKSLB is a helper function to simplify the provider implementation.
@@ -70,80 +79,116 @@ func (r *iksLBResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 		Attributes: map[string]schema.Attribute{
 			"cluster_uuid": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
+			"cloudaccount": cloudaccountAttribute(),
+			"region":       regionAttribute(),
 		},
 		Blocks: map[string]schema.Block{
-			"load_balancers": schema.SingleNestedBlock{
-				Description: "List of load balancers to be provisioned.",
-				Attributes: map[string]schema.Attribute{
-					"id": schema.StringAttribute{
-						Computed: true,
-					},
-					"name": schema.StringAttribute{
-						Required:    true,
-						Description: "Name of the load balancer.",
-					},
-					"schema": schema.StringAttribute{
-						Required:    true,
-						Description: "Schema under which the load balancer is created.",
+			"load_balancers": schema.ListNestedBlock{
+				Description: "List of load balancers to be provisioned. Load balancers are matched by name on update.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								iksLoadBalancerIDModifier{},
+							},
+						},
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "Name of the load balancer.",
+						},
+						"schema": schema.StringAttribute{
+							Required:    true,
+							Description: "Schema under which the load balancer is created. Changing it recreates the load balancer.",
+						},
+						"vip": schema.StringAttribute{
+							Computed:    true,
+							Description: "Virtual IP of the load balancer.",
+						},
+						"state": schema.StringAttribute{
+							Computed: true,
+						},
+						"listener_status": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.ObjectType{AttrTypes: models.LoadBalancerListenerStatusAttributes},
+							Description: "Status of each listener, including its pool members, as reported by the load balancer service.",
+						},
 					},
-				},
-				Blocks: map[string]schema.Block{
-					"listeners": schema.ListNestedBlock{
-						Description: "List of listener configurations.",
-						NestedObject: schema.NestedBlockObject{
-							Attributes: map[string]schema.Attribute{
-								"port": schema.Int64Attribute{
-									Required:    true,
-									Description: "Listener port.",
-								},
-								"protocol": schema.StringAttribute{
-									Required:    true,
-									Description: "Listener protocol (e.g., LBProtocolTCP).",
+					Blocks: map[string]schema.Block{
+						"listeners": schema.ListNestedBlock{
+							Description: "List of listener configurations.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"port": schema.Int64Attribute{
+										Required:    true,
+										Description: "Listener port.",
+									},
+									"protocol": schema.StringAttribute{
+										Required:    true,
+										Description: "Listener protocol (e.g., LBProtocolTCP).",
+									},
 								},
-							},
-							Blocks: map[string]schema.Block{
-								"pool": schema.SingleNestedBlock{
-									Description: "Pool configuration for the listener.",
-									Attributes: map[string]schema.Attribute{
-										"port": schema.Int64Attribute{
-											Required:    true,
-											Description: "Pool port.",
-										},
-										"monitor": schema.StringAttribute{
-											Required:    true,
-											Description: "Health monitor type (e.g., https).",
-										},
-										"load_balancing_mode": schema.StringAttribute{
-											Required:    true,
-											Description: "Load balancing mode (e.g., roundRobin).",
-										},
-										"node_group_id": schema.StringAttribute{
-											Required:    true,
-											Description: "ID of the associated node group.",
+								Blocks: map[string]schema.Block{
+									"pool": schema.SingleNestedBlock{
+										Description: "Pool configuration for the listener. Exactly one of node_group_id, node_group_name, instance_resource_ids or instance_selectors selects the pool members.",
+										Attributes: map[string]schema.Attribute{
+											"port": schema.Int64Attribute{
+												Required:    true,
+												Description: "Pool port.",
+											},
+											"monitor": schema.StringAttribute{
+												Required:    true,
+												Description: "Health monitor type (e.g., https).",
+											},
+											"load_balancing_mode": schema.StringAttribute{
+												Required:    true,
+												Description: "Load balancing mode (e.g., roundRobin).",
+											},
+											"node_group_id": schema.StringAttribute{
+												Optional:    true,
+												Description: "ID of the node group whose nodes are the pool members.",
+											},
+											"node_group_name": schema.StringAttribute{
+												Optional:    true,
+												Description: "Name of the node group whose nodes are the pool members.",
+											},
+											"instance_resource_ids": schema.ListAttribute{
+												ElementType: types.StringType,
+												Optional:    true,
+												Description: "IDs of the instances that are the pool members.",
+											},
+											"instance_selectors": schema.MapAttribute{
+												ElementType: types.StringType,
+												Optional:    true,
+												Description: "Labels selecting the instances that are the pool members.",
+											},
 										},
 									},
-								},
-								"security": schema.SingleNestedBlock{
-									Description: "Security configuration for the load balancer listener.",
-									Attributes: map[string]schema.Attribute{
-										"source_ips": schema.ListAttribute{
-											ElementType: types.StringType,
-											Required:    true,
-											Description: "List of allowed source IPs.",
+									"security": schema.SingleNestedBlock{
+										Description: "Security configuration for the load balancer listener.",
+										Attributes: map[string]schema.Attribute{
+											"source_ips": schema.ListAttribute{
+												ElementType: types.StringType,
+												Required:    true,
+												Description: "List of allowed source IPs.",
+											},
 										},
 									},
 								},
 							},
 						},
-					},
-					"security": schema.SingleNestedBlock{
-						Description: "Security configuration for the load balancer.",
-						Attributes: map[string]schema.Attribute{
-							"source_ips": schema.ListAttribute{
-								ElementType: types.StringType,
-								Required:    true,
-								Description: "List of allowed source IPs.",
+						"security": schema.SingleNestedBlock{
+							Description: "Security configuration for the load balancer.",
+							Attributes: map[string]schema.Attribute{
+								"source_ips": schema.ListAttribute{
+									ElementType: types.StringType,
+									Required:    true,
+									Description: "List of allowed source IPs.",
+								},
 							},
 						},
 					},
@@ -155,7 +200,7 @@ func (r *iksLBResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 						Optional:    true,
 						Computed:    true,
 						Description: "Timeout for loadbalancer resource operations",
-						Default:     stringdefault.StaticString(IKSLoadBalancerResourceName),
+						Default:     stringdefault.StaticString(IKSLoadBalancerResourceTimeout),
 					},
 				},
 			},
@@ -163,6 +208,50 @@ func (r *iksLBResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 	}
 }
 
+// ValidateConfig checks that load balancer names are unique, as they are used to match load balancers
+// on update, and that every pool has a single target.
+func (r *iksLBResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config iksLoadBalancerResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names := map[string]bool{}
+	for i, lb := range config.LoadBalancers {
+		if lb.Name.IsNull() || lb.Name.IsUnknown() {
+			continue
+		}
+		if names[lb.Name.ValueString()] {
+			resp.Diagnostics.AddAttributeError(path.Root("load_balancers").AtListIndex(i).AtName("name"),
+				"Duplicate load balancer name",
+				fmt.Sprintf("load balancer %s is defined more than once", lb.Name.ValueString()))
+		}
+		names[lb.Name.ValueString()] = true
+
+		for j, listener := range lb.Listeners {
+			pool := listener.Pool
+			if pool.Port.IsNull() {
+				continue
+			}
+			targets := 0
+			for _, v := range []attr.Value{pool.NodeGroupId, pool.NodeGroupName, pool.InstanceResourceIds, pool.InstanceSelectors} {
+				if !v.IsNull() {
+					targets++
+				}
+			}
+			if targets != 1 {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("load_balancers").AtListIndex(i).AtName("listeners").AtListIndex(j).AtName("pool"),
+					"Invalid load balancer pool",
+					"Exactly one of node_group_id, node_group_name, instance_resource_ids or instance_selectors must be set")
+			}
+		}
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *iksLBResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -183,40 +272,42 @@ func (r *iksLBResource) Create(ctx context.Context, req resource.CreateRequest,
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
-	inArg := itacservices.IKSLoadbalancerCreateRequest{
-		Metadata: itacservices.IKSLoadBalancerCreateMetadata{
-			Name:        plan.LoadBalancer.Name.ValueString(),
-			ClusterUUID: plan.ClusterUUID.ValueString(),
-		},
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	for _, listener := range plan.LoadBalancer.Listeners {
-		inArg.Spec.Listeners = append(inArg.Spec.Listeners, itacservices.IKSLoadBalancerListener{
-			Port:     listener.Port.ValueInt64(),
-			Protocol: listener.Protocol.ValueString(),
-			Pool: itacservices.IKSLoadBalancerPool{
-				Port:              listener.Pool.Port.ValueInt64(),
-				Monitor:           listener.Pool.Monitor.ValueString(),
-				LoadBalancingMode: listener.Pool.LoadBalancingMode.ValueString(),
-				NodeGroupID:       listener.Pool.NodeGroupId.ValueString(),
-			},
-			Security: itacservices.IKSLoadBalancerSecurity{
-				SourceIps: convertTFStringsToGoStrings(listener.Security.SourceIps),
-			},
-		})
-		inArg.Spec.Security.SourceIps = convertTFStringsToGoStrings(plan.LoadBalancer.Security.SourceIps)
-		inArg.Spec.Schema = plan.LoadBalancer.Schema.ValueString()
+	plan.Region = types.StringValue(*client.Region)
+	plan.Cloudaccount = types.StringValue(*client.Cloudaccount)
 
-		ilbResp, _, err := r.client.CreateIKSLoadBalancer(ctx, &inArg, plan.ClusterUUID.ValueString())
+	created := []models.IKSLoadBalancer{}
+	for _, lb := range plan.LoadBalancers {
+		ilbResp, err := createIKSLoadBalancer(ctx, client, plan.ClusterUUID.ValueString(), lb)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error creating iks load balancer",
-				"Could not create iks load balancer, unexpected error: "+err.Error(),
+				"Could not create iks load balancer "+lb.Name.ValueString()+", unexpected error: "+err.Error(),
 			)
+			// keep track of the load balancers created so far
+			plan.LoadBalancers = created
+			resp.State.Set(ctx, plan)
 			return
 		}
-
-		plan.LoadBalancer.ID = types.StringValue(ilbResp.Metadata.ResourceID)
+		lb.ID = types.StringValue(ilbResp.Metadata.ResourceID)
+		lb.Vip = types.StringValue(ilbResp.Status.VIP)
+		lb.State = types.StringValue(ilbResp.Status.State)
+		lb.ListenerStatus, err = loadBalancerListenerStatusFromAPI(ctx, ilbResp.Status.Listeners)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating iks load balancer",
+				"Could not read status of iks load balancer "+lb.Name.ValueString()+", unexpected error: "+err.Error(),
+			)
+			// the load balancer exists, keep it in the state
+			lb.ListenerStatus = types.ListNull(types.ObjectType{AttrTypes: models.LoadBalancerListenerStatusAttributes})
+		}
+		created = append(created, lb)
 	}
+	plan.LoadBalancers = created
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -245,7 +336,21 @@ func (r *iksLBResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
 
-	currState, err := r.refreshIKSLoadBalancerResourceModel(ctx, state)
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Region = types.StringValue(*client.Region)
+	state.Cloudaccount = types.StringValue(*client.Cloudaccount)
+
+	// load balancers that no longer exist are left out of the refreshed state
+	currState, err := refreshIKSLoadBalancerResourceModel(ctx, client, state)
+	if common.IsNotFound(err) {
+		tflog.Warn(ctx, "iks cluster not found, removing load balancers from state", map[string]any{"cluster_uuid": state.ClusterUUID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading IKS Load Balancer resource",
@@ -290,53 +395,112 @@ func (r *iksLBResource) Update(ctx context.Context, req resource.UpdateRequest,
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
 
-	lb := plan.LoadBalancer
-	// Check if the load balancer already exists in the state
-	lbExists, lbID := r.checkLBExistsAndGetID(ctx, plan.ClusterUUID.ValueString(), lb.Name.ValueString())
-	if lbExists {
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Region = types.StringValue(*client.Region)
+	plan.Cloudaccount = types.StringValue(*client.Cloudaccount)
+
+	clusterUUID := state.ClusterUUID.ValueString()
+	removed, changes := diffIKSLoadBalancers(state.LoadBalancers, plan.LoadBalancers)
+
+	// Remove load balancers that are no longer configured
+	for _, lb := range removed {
+		tflog.Info(ctx, "Deleting IKS Load Balancer", map[string]any{"Name": lb.Name.ValueString(), "ID": lb.ID.ValueString()})
+		if err := client.DeleteIKSLoadBalancer(ctx, clusterUUID, lb.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting IKS Load Balancer",
+				"Could not delete IKS Load Balancer with ID "+lb.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		if err := client.WaitForIKSLoadBalancerDeleted(ctx, clusterUUID, lb.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting IKS Load Balancer",
+				"Could not delete IKS Load Balancer with ID "+lb.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	for _, change := range changes {
+		lb, existing := change.planned, change.existing
+		switch change.action {
+		case iksLoadBalancerUnchanged:
+			tflog.Info(ctx, "no change detected in load balancer spec, skipping update", map[string]any{"Name": lb.Name.ValueString()})
+			continue
+		case iksLoadBalancerReplace:
+			// the schema cannot be changed in place
+			tflog.Info(ctx, "Recreating IKS Load Balancer", map[string]any{"Name": lb.Name.ValueString(), "ID": existing.ID.ValueString()})
+			if err := client.DeleteIKSLoadBalancer(ctx, clusterUUID, existing.ID.ValueString()); err != nil {
+				resp.Diagnostics.AddError(
+					"Error deleting IKS Load Balancer",
+					"Could not delete IKS Load Balancer with ID "+existing.ID.ValueString()+": "+err.Error(),
+				)
+				return
+			}
+			// the replacement reuses the name, so wait for the old one to go
+			if err := client.WaitForIKSLoadBalancerDeleted(ctx, clusterUUID, existing.ID.ValueString()); err != nil {
+				resp.Diagnostics.AddError(
+					"Error deleting IKS Load Balancer",
+					"Could not delete IKS Load Balancer with ID "+existing.ID.ValueString()+": "+err.Error(),
+				)
+				return
+			}
+		case iksLoadBalancerCreate:
+			// a load balancer with the same name may already exist on the cluster
+			if lbExists, lbID := checkLBExistsAndGetID(ctx, client, clusterUUID, lb.Name.ValueString()); lbExists {
+				existing = models.IKSLoadBalancer{ID: types.StringValue(lbID)}
+				change.action = iksLoadBalancerUpdate
+			}
+		}
+
+		if change.action != iksLoadBalancerUpdate {
+			tflog.Info(ctx, "Creating IKS Load Balancer", map[string]any{"Name": lb.Name.ValueString()})
+			if _, err := createIKSLoadBalancer(ctx, client, clusterUUID, lb); err != nil {
+				resp.Diagnostics.AddError(
+					"Error creating iks load balancer",
+					"Could not create iks load balancer "+lb.Name.ValueString()+", unexpected error: "+err.Error(),
+				)
+				return
+			}
+			continue
+		}
+
 		// Update existing load balancer
 		tflog.Info(ctx, "Updating existing IKS Load Balancer", map[string]any{"Name": lb.Name.ValueString()})
+		listeners, diags := iksLoadBalancerListenersFromModel(ctx, lb)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 		inArg := itacservices.IKSLoadBalancerUpdateRequest{
 			Metadata: itacservices.IKSLoadBalancerUpdateMetadata{
-				ResourceId: lb.ID.ValueString(),
+				ResourceId: existing.ID.ValueString(),
 			},
 			Spec: itacservices.IKSLoadBalancerUpdateSpec{
 				Security: itacservices.IKSLoadBalancerSecurity{
 					SourceIps: convertTFStringsToGoStrings(lb.Security.SourceIps),
 				},
-				Listeners: []itacservices.IKSLoadBalancerListener{},
+				Listeners: listeners,
 			},
 		}
-		for _, listener := range lb.Listeners {
-			inArg.Spec.Listeners = append(inArg.Spec.Listeners, itacservices.IKSLoadBalancerListener{
-				Port:     listener.Port.ValueInt64(),
-				Protocol: listener.Protocol.ValueString(),
-				Pool: itacservices.IKSLoadBalancerPool{
-					Port:              listener.Pool.Port.ValueInt64(),
-					Monitor:           listener.Pool.Monitor.ValueString(),
-					LoadBalancingMode: listener.Pool.LoadBalancingMode.ValueString(),
-					NodeGroupID:       listener.Pool.NodeGroupId.ValueString(),
-				},
-				Security: itacservices.IKSLoadBalancerSecurity{
-					SourceIps: convertTFStringsToGoStrings(listener.Security.SourceIps),
-				},
-			})
-		}
 		// Call the update API
-		err := r.client.UpdateIKSLoadBalancer(ctx, &inArg, state.ClusterUUID.ValueString(), lbID)
+		err := client.UpdateIKSLoadBalancer(ctx, &inArg, clusterUUID, existing.ID.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error updating IKS Load Balancer",
-				"Could not update IKS Load Balancer with ID "+lb.ID.ValueString()+": "+err.Error(),
+				"Could not update IKS Load Balancer with ID "+existing.ID.ValueString()+": "+err.Error(),
 			)
 			return
 		}
-		tflog.Info(ctx, "Successfully updated IKS Load Balancer", map[string]any{"ID": lb.ID.ValueString()})
+		tflog.Info(ctx, "Successfully updated IKS Load Balancer", map[string]any{"ID": existing.ID.ValueString()})
 	}
 
-	// Get refreshed order value from IDC Service irrespective of whether upgrade was done or skipped
-	plan.LoadBalancer.ID = types.StringValue(lbID)
-	currState, err := r.refreshIKSLoadBalancerResourceModel(ctx, &plan)
+	// Get refreshed order value from IDC Service irrespective of whether update was done or skipped
+	currState, err := refreshIKSLoadBalancerResourceModel(ctx, client, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading IKS Loadbalancer resource",
@@ -351,7 +515,6 @@ func (r *iksLBResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	tflog.Info(ctx, "no change detected change in cluster spec, skipping update")
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -373,47 +536,87 @@ func (r *iksLBResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
 
-	// Check if the load balancer exists before attempting to delete
-	tflog.Info(ctx, "Deleting IKS Load Balancer", map[string]any{"ID": state.LoadBalancer.ID.ValueString()})
-	// Call the delete API
-	err = r.client.DeleteIKSLoadBalancer(ctx, state.ClusterUUID.ValueString(), state.LoadBalancer.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting IKS Load Balancer",
-			"Could not delete IKS Load Balancer with ID "+state.LoadBalancer.ID.ValueString()+": "+err.Error(),
-		)
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	tflog.Info(ctx, "Successfully deleted IKS Load Balancer", map[string]any{"ID": state.LoadBalancer.ID.ValueString()})
 
+	for _, lb := range state.LoadBalancers {
+		tflog.Info(ctx, "Deleting IKS Load Balancer", map[string]any{"ID": lb.ID.ValueString()})
+		// Call the delete API
+		err = client.DeleteIKSLoadBalancer(ctx, state.ClusterUUID.ValueString(), lb.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting IKS Load Balancer",
+				"Could not delete IKS Load Balancer with ID "+lb.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		if err := client.WaitForIKSLoadBalancerDeleted(ctx, state.ClusterUUID.ValueString(), lb.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting IKS Load Balancer",
+				"Could not delete IKS Load Balancer with ID "+lb.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		tflog.Info(ctx, "Successfully deleted IKS Load Balancer", map[string]any{"ID": lb.ID.ValueString()})
+	}
 }
 
 func (r *iksLBResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	// Expect import ID in the format: cluster_id:id
-	ids := strings.Split(req.ID, ":")
-	if len(ids) != 2 {
+	scope, diags := resolveImportScope(ctx, r.client, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Expect import ID in the format: cluster:lb[,lb...], each given by ID or by name
+	ids := strings.Split(scope.id, ":")
+	if len(ids) != 2 || ids[0] == "" || ids[1] == "" {
 		resp.Diagnostics.AddError(
 			"Invalid import format",
-			"Expected import ID in the format 'cluster_id:id'. Example: abc123:def456",
+			"Expected import ID in the format 'cluster:lb[,lb...]', each given by ID or name. Example: abc123:def456",
 		)
 		return
 	}
 
-	clusterUUID := ids[0]
-	lbId := ids[1]
+	clusterUUID, err := resolveIKSClusterImportID(ctx, scope.client, ids[0])
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import IKS Load Balancer", err.Error())
+		return
+	}
 
-	// Basic validation
-	if clusterUUID == "" {
+	lbs, err := scope.client.GetIKSLoadBalancerByClusterUUID(ctx, clusterUUID)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			"Expected import ID to be the cluster UUID, got empty string.",
+			"Unable to import IKS Load Balancer",
+			fmt.Sprintf("Error retrieving load balancers for cluster %s %s", clusterUUID, err.Error()),
 		)
 		return
 	}
+	candidates := []importCandidate{}
+	for _, item := range lbs.Items {
+		candidates = append(candidates, importCandidate{ID: item.Metadata.ResourceID, Name: item.Metadata.Name})
+	}
+
+	state := &iksLoadBalancerResourceModel{
+		ClusterUUID:  types.StringValue(clusterUUID),
+		Region:       scope.region(),
+		Cloudaccount: scope.cloudaccount(),
+	}
+	for _, lbRef := range strings.Split(ids[1], ",") {
+		lbId, err := resolveImportID("iks load balancer", lbRef, candidates)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to import IKS Load Balancer", err.Error())
+			return
+		}
+		state.LoadBalancers = append(state.LoadBalancers, models.IKSLoadBalancer{
+			ID: types.StringValue(lbId),
+		})
+	}
 
-	// Fetch LBs for this cluster
-	lb, err := r.client.GetIKSLoadBalancerByID(ctx, clusterUUID, lbId)
+	currState, err := refreshIKSLoadBalancerResourceModel(ctx, scope.client, state)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to import IKS Load Balancer",
@@ -422,59 +625,226 @@ func (r *iksLBResource) ImportState(ctx context.Context, req resource.ImportStat
 		return
 	}
 
-	// Convert API response to state model
-	var listeners []models.IKSLoadBalancerListenerModel
-	for _, l := range lb.Spec.Listeners {
-		var sourceIps []types.String
-		for _, ip := range l.Security.SourceIps {
-			sourceIps = append(sourceIps, types.StringValue(ip))
+	// Set the full state
+	resp.Diagnostics.Append(resp.State.Set(ctx, currState)...)
+}
+
+// refreshIKSLoadBalancerResourceModel reads the load balancers of the cluster and maps the ones
+// tracked in the given model, matched by ID or else by name. Load balancers that no longer
+// exist are left out of the returned model.
+func refreshIKSLoadBalancerResourceModel(ctx context.Context, client *itacservices.IDCServicesClient, plan *iksLoadBalancerResourceModel) (*iksLoadBalancerResourceModel, error) {
+	state := &iksLoadBalancerResourceModel{}
+	state.ClusterUUID = plan.ClusterUUID
+	state.Cloudaccount = plan.Cloudaccount
+	state.Region = plan.Region
+	// set timeout again for consistency
+	state.Timeouts = plan.Timeouts
+
+	lbs, err := client.GetIKSLoadBalancerByClusterUUID(ctx, plan.ClusterUUID.ValueString())
+	if err != nil {
+		return state, fmt.Errorf("error fetching IKS Load Balancers for cluster %s: %w", plan.ClusterUUID.ValueString(), err)
+	}
+
+	nodeGroupIDs := getNodeGroupIDsByName(ctx, client, plan.ClusterUUID.ValueString())
+
+	for _, lb := range plan.LoadBalancers {
+		var found *itacservices.IKSLoadBalancerItems
+		for i := range lbs.Items {
+			item := &lbs.Items[i]
+			if !lb.ID.IsUnknown() && !lb.ID.IsNull() && lb.ID.ValueString() != "" {
+				if item.Metadata.ResourceID == lb.ID.ValueString() {
+					found = item
+					break
+				}
+			} else if item.Metadata.Name == lb.Name.ValueString() {
+				found = item
+				break
+			}
 		}
-		listeners = append(listeners, models.IKSLoadBalancerListenerModel{
-			Port:     types.Int64Value(int64(l.Port)),
-			Protocol: types.StringValue(string(l.Protocol)),
-			Security: models.IKSLoadBalancerSecurityModel{
-				SourceIps: sourceIps,
-			},
-			Pool: models.IKSLoadBalancerPoolModel{
-				Port:              types.Int64Value(int64(l.Pool.Port)),
-				Monitor:           types.StringValue(string(l.Pool.Monitor)),
-				LoadBalancingMode: types.StringValue(string(l.Pool.LoadBalancingMode)),
-				NodeGroupId:       types.StringValue(l.Pool.InstanceSelectors["nodegroupName"]),
-			},
-		})
+		if found == nil {
+			tflog.Warn(ctx, "IKS Load Balancer not found", map[string]any{"Name": lb.Name.ValueString(), "ID": lb.ID.ValueString()})
+			continue
+		}
+		lbModel, err := iksLoadBalancerModelFromItem(ctx, found, &lb, nodeGroupIDs)
+		if err != nil {
+			return state, err
+		}
+		state.LoadBalancers = append(state.LoadBalancers, lbModel)
 	}
-	var securitySourceIps []types.String
-	for _, ip := range lb.Spec.Security.SourceIps {
-		securitySourceIps = append(securitySourceIps, types.StringValue(ip))
+
+	return state, nil
+}
+
+// The ways Update brings a configured load balancer in line with the cluster.
+const (
+	iksLoadBalancerUnchanged = iota
+	iksLoadBalancerUpdate
+	iksLoadBalancerReplace
+	iksLoadBalancerCreate
+)
+
+// iksLoadBalancerChange is the change Update makes for one planned load balancer, next to the
+// load balancer in state with the same name, if any.
+type iksLoadBalancerChange struct {
+	action   int
+	planned  models.IKSLoadBalancer
+	existing models.IKSLoadBalancer
+}
+
+// diffIKSLoadBalancers matches the planned load balancers to the ones in state by name, so
+// reordering the configuration changes nothing. It returns the load balancers in state that are
+// no longer configured and the change for each planned one.
+func diffIKSLoadBalancers(state, plan []models.IKSLoadBalancer) ([]models.IKSLoadBalancer, []iksLoadBalancerChange) {
+	current := map[string]models.IKSLoadBalancer{}
+	for _, lb := range state {
+		current[lb.Name.ValueString()] = lb
 	}
-	lbModel := models.IKSLoadBalancer{
-		ID:   types.StringValue(lb.Metadata.ResourceID),
-		Name: types.StringValue(lb.Metadata.Name),
-		Security: models.IKSLoadBalancerSecurityModel{
-			SourceIps: securitySourceIps,
-		},
-		Schema:    types.StringValue(string(lb.Spec.Schema)),
-		Listeners: listeners,
+	planned := map[string]bool{}
+	for _, lb := range plan {
+		planned[lb.Name.ValueString()] = true
 	}
 
-	// Set the full state
-	resp.State.Set(ctx, &iksLoadBalancerResourceModel{
-		ClusterUUID:  types.StringValue(clusterUUID),
-		LoadBalancer: lbModel,
-	})
+	removed := []models.IKSLoadBalancer{}
+	for _, lb := range state {
+		if !planned[lb.Name.ValueString()] {
+			removed = append(removed, lb)
+		}
+	}
 
-	// Set the import state
-	resp.State.SetAttribute(ctx, path.Root("cluster_uuid"), clusterUUID)
+	changes := []iksLoadBalancerChange{}
+	for _, lb := range plan {
+		change := iksLoadBalancerChange{planned: lb}
+		existing, found := current[lb.Name.ValueString()]
+		switch {
+		case !found:
+			change.action = iksLoadBalancerCreate
+		case !existing.Schema.Equal(lb.Schema):
+			change.action = iksLoadBalancerReplace
+		case !reflect.DeepEqual(existing.Listeners, lb.Listeners) || !reflect.DeepEqual(existing.Security, lb.Security):
+			change.action = iksLoadBalancerUpdate
+		}
+		if found {
+			change.existing = existing
+		}
+		changes = append(changes, change)
+	}
+	return removed, changes
 }
 
-func (r *iksLBResource) refreshIKSLoadBalancerResourceModel(ctx context.Context, plan *iksLoadBalancerResourceModel) (*iksLoadBalancerResourceModel, error) {
-	state := &iksLoadBalancerResourceModel{}
-	state.ClusterUUID = plan.ClusterUUID
-	lb := plan.LoadBalancer
-	loadbalancer, err := r.client.GetIKSLoadBalancerByID(ctx, plan.ClusterUUID.ValueString(), lb.ID.ValueString())
+// iksLoadBalancerIDModifier plans the id of a load balancer the way Update matches it, by name
+// rather than by list index, so reordering or removing load balancers keeps each id with its
+// load balancer.
+type iksLoadBalancerIDModifier struct{}
+
+func (m iksLoadBalancerIDModifier) Description(_ context.Context) string {
+	return "Keeps the id of a load balancer whose name and schema are unchanged."
+}
+
+func (m iksLoadBalancerIDModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m iksLoadBalancerIDModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var name, lbSchema types.String
+	var state []models.IKSLoadBalancer
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, req.Path.ParentPath().AtName("name"), &name)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, req.Path.ParentPath().AtName("schema"), &lbSchema)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("load_balancers"), &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.PlanValue = iksLoadBalancerPlannedID(state, name, lbSchema)
+}
+
+// iksLoadBalancerPlannedID returns the id in state of the load balancer with the name. It is
+// unknown for a new name and for a changed schema, which Update replaces.
+func iksLoadBalancerPlannedID(state []models.IKSLoadBalancer, name, lbSchema types.String) types.String {
+	for _, lb := range state {
+		if lb.Name.Equal(name) && lb.Schema.Equal(lbSchema) && !lb.ID.IsNull() {
+			return lb.ID
+		}
+	}
+	return types.StringUnknown()
+}
+
+// getNodeGroupIDsByName maps the names of the cluster node groups to their IDs.
+func getNodeGroupIDsByName(ctx context.Context, client *itacservices.IDCServicesClient, clusterUUID string) map[string]string {
+	nodeGroupIDs := map[string]string{}
+	cluster, _, err := client.GetIKSClusterByClusterUUID(ctx, clusterUUID)
 	if err != nil {
-		return state, fmt.Errorf("error fetching IKS Load Balancer with ID %s: %w", lb.ID.ValueString(), err)
+		tflog.Warn(ctx, "Could not read IKS cluster node groups", map[string]any{"cluster_uuid": clusterUUID, "error": err.Error()})
+		return nodeGroupIDs
+	}
+	for _, ng := range cluster.NodeGroups {
+		nodeGroupIDs[ng.Name] = ng.ID
+	}
+	return nodeGroupIDs
+}
+
+// createIKSLoadBalancer creates a load balancer with all its listeners in a single request.
+func createIKSLoadBalancer(ctx context.Context, client *itacservices.IDCServicesClient, clusterUUID string, lb models.IKSLoadBalancer) (*itacservices.IKSLoadBalancerItems, error) {
+	listeners, diags := iksLoadBalancerListenersFromModel(ctx, lb)
+	if diags.HasError() {
+		return nil, fmt.Errorf("error parsing load balancer listeners")
 	}
+	inArg := itacservices.IKSLoadbalancerCreateRequest{
+		Metadata: itacservices.IKSLoadBalancerCreateMetadata{
+			Name:        lb.Name.ValueString(),
+			ClusterUUID: clusterUUID,
+		},
+		Spec: itacservices.IKSLoadBalancerSpec{
+			Listeners: listeners,
+			Security: itacservices.IKSLoadBalancerSecurity{
+				SourceIps: convertTFStringsToGoStrings(lb.Security.SourceIps),
+			},
+			Schema: lb.Schema.ValueString(),
+		},
+	}
+
+	ilbResp, _, err := client.CreateIKSLoadBalancer(ctx, &inArg, clusterUUID)
+	return ilbResp, err
+}
+
+func iksLoadBalancerListenersFromModel(ctx context.Context, lb models.IKSLoadBalancer) ([]itacservices.IKSLoadBalancerListener, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	listeners := []itacservices.IKSLoadBalancerListener{}
+	for _, listener := range lb.Listeners {
+		pool := itacservices.IKSLoadBalancerPool{
+			Port:              listener.Pool.Port.ValueInt64(),
+			Monitor:           listener.Pool.Monitor.ValueString(),
+			LoadBalancingMode: listener.Pool.LoadBalancingMode.ValueString(),
+			NodeGroupID:       listener.Pool.NodeGroupId.ValueString(),
+		}
+		if !listener.Pool.NodeGroupName.IsNull() {
+			pool.InstanceSelectors = map[string]string{
+				itacservices.IKSNodeGroupNameSelector: listener.Pool.NodeGroupName.ValueString(),
+			}
+		}
+		if !listener.Pool.InstanceSelectors.IsNull() {
+			diags.Append(listener.Pool.InstanceSelectors.ElementsAs(ctx, &pool.InstanceSelectors, false)...)
+		}
+		if !listener.Pool.InstanceResourceIds.IsNull() {
+			diags.Append(listener.Pool.InstanceResourceIds.ElementsAs(ctx, &pool.InstanceResourceIds, false)...)
+		}
+		listeners = append(listeners, itacservices.IKSLoadBalancerListener{
+			Port:     listener.Port.ValueInt64(),
+			Protocol: listener.Protocol.ValueString(),
+			Pool:     pool,
+			Security: itacservices.IKSLoadBalancerSecurity{
+				SourceIps: convertTFStringsToGoStrings(listener.Security.SourceIps),
+			},
+		})
+	}
+	return listeners, diags
+}
+
+// iksLoadBalancerModelFromItem maps a load balancer read from the API to its model. The prior
+// model, when known, decides how node group pool targets are reported back.
+func iksLoadBalancerModelFromItem(ctx context.Context, loadbalancer *itacservices.IKSLoadBalancerItems, prior *models.IKSLoadBalancer, nodeGroupIDs map[string]string) (models.IKSLoadBalancer, error) {
 	var securitySourceIps []types.String
 	for _, ip := range loadbalancer.Spec.Security.SourceIps {
 		securitySourceIps = append(securitySourceIps, types.StringValue(ip))
@@ -485,50 +855,103 @@ func (r *iksLBResource) refreshIKSLoadBalancerResourceModel(ctx context.Context,
 		for _, ip := range listener.Security.SourceIps {
 			sourceIps = append(sourceIps, types.StringValue(ip))
 		}
+		var priorPool *models.IKSLoadBalancerPoolModel
+		if prior != nil {
+			for i := range prior.Listeners {
+				if prior.Listeners[i].Port.ValueInt64() == int64(listener.Port) {
+					priorPool = &prior.Listeners[i].Pool
+					break
+				}
+			}
+		}
 		listeners = append(listeners, models.IKSLoadBalancerListenerModel{
 			Port:     types.Int64Value(int64(listener.Port)),
 			Protocol: types.StringValue(string(listener.Protocol)),
 			Security: models.IKSLoadBalancerSecurityModel{
 				SourceIps: sourceIps,
 			},
-			Pool: models.IKSLoadBalancerPoolModel{
-				Port:              types.Int64Value(int64(listener.Pool.Port)),
-				Monitor:           types.StringValue(string(listener.Pool.Monitor)),
-				LoadBalancingMode: types.StringValue(string(listener.Pool.LoadBalancingMode)),
-				NodeGroupId:       types.StringValue(listener.Pool.InstanceSelectors["nodegroupName"]),
-			},
+			Pool: iksLoadBalancerPoolModelFromAPI(listener.Pool, priorPool, nodeGroupIDs),
 		})
 	}
-	state.LoadBalancer = models.IKSLoadBalancer{
+	listenerStatus, err := loadBalancerListenerStatusFromAPI(ctx, loadbalancer.Status.Listeners)
+	if err != nil {
+		return models.IKSLoadBalancer{}, err
+	}
+	return models.IKSLoadBalancer{
 		ID:   types.StringValue(loadbalancer.Metadata.ResourceID),
 		Name: types.StringValue(loadbalancer.Metadata.Name),
 		Security: models.IKSLoadBalancerSecurityModel{
 			SourceIps: securitySourceIps,
 		},
-		Schema:    types.StringValue(string(loadbalancer.Spec.Schema)),
-		Listeners: listeners,
-	}
+		Schema:         types.StringValue(string(loadbalancer.Spec.Schema)),
+		Listeners:      listeners,
+		Vip:            types.StringValue(loadbalancer.Status.VIP),
+		State:          types.StringValue(loadbalancer.Status.State),
+		ListenerStatus: listenerStatus,
+	}, nil
+}
 
-	// set timeout again for consistency
-	state.Timeouts = plan.Timeouts
+// iksLoadBalancerPoolModelFromAPI maps the pool members back to the attribute they were selected with.
+// The API reports node group targets as a nodegroupName instance selector, which is reported as
+// node_group_name when configured that way and as the node group ID otherwise.
+func iksLoadBalancerPoolModelFromAPI(pool itacservices.LoadBalancerPool, prior *models.IKSLoadBalancerPoolModel, nodeGroupIDs map[string]string) models.IKSLoadBalancerPoolModel {
+	model := models.IKSLoadBalancerPoolModel{
+		Port:                types.Int64Value(int64(pool.Port)),
+		Monitor:             types.StringValue(pool.Monitor),
+		LoadBalancingMode:   types.StringValue(pool.LoadBalancingMode),
+		NodeGroupId:         types.StringNull(),
+		NodeGroupName:       types.StringNull(),
+		InstanceResourceIds: types.ListNull(types.StringType),
+		InstanceSelectors:   types.MapNull(types.StringType),
+	}
+
+	if len(pool.InstanceResourceIds) > 0 {
+		model.InstanceResourceIds = stringListValue(pool.InstanceResourceIds)
+	}
+
+	ngName, byNodeGroup := pool.InstanceSelectors[itacservices.IKSNodeGroupNameSelector]
+	byNodeGroup = byNodeGroup && len(pool.InstanceSelectors) == 1
+	selectorsConfigured := prior != nil && !prior.InstanceSelectors.IsNull()
+
+	switch {
+	case byNodeGroup && prior != nil && !prior.NodeGroupName.IsNull():
+		model.NodeGroupName = types.StringValue(ngName)
+	case byNodeGroup && !selectorsConfigured && nodeGroupIDs[ngName] != "":
+		model.NodeGroupId = types.StringValue(nodeGroupIDs[ngName])
+	case byNodeGroup && !selectorsConfigured && prior != nil && !prior.NodeGroupId.IsNull():
+		// the node group could not be resolved, keep the configured ID
+		model.NodeGroupId = prior.NodeGroupId
+	case byNodeGroup && !selectorsConfigured:
+		model.NodeGroupName = types.StringValue(ngName)
+	case len(pool.InstanceSelectors) > 0:
+		selectors := map[string]attr.Value{}
+		for k, v := range pool.InstanceSelectors {
+			selectors[k] = types.StringValue(v)
+		}
+		model.InstanceSelectors = types.MapValueMust(types.StringType, selectors)
+	}
 
-	return state, nil
+	return model
 }
 
-func (r *iksLBResource) checkLBExistsAndGetID(ctx context.Context, clusteruuid, lbName string) (bool, string) {
-	lb, err := r.client.GetIKSLoadBalancerByClusterUUID(ctx, clusteruuid)
+func checkLBExistsAndGetID(ctx context.Context, client *itacservices.IDCServicesClient, clusteruuid, lbName string) (bool, string) {
+	it, err := client.ListIKSLoadBalancersByCluster(clusteruuid)
 	if err != nil {
 		tflog.Error(ctx, "Error checking if IKS Load Balancer exists", map[string]any{"error": err.Error()})
 		return false, ""
 	}
 
-	if len(lb.Items) > 0 {
-		for _, item := range lb.Items {
+	// stop at the first page holding a match instead of listing every load balancer
+	for it.Next(ctx) {
+		for _, item := range it.Page() {
 			if item.Metadata.Name == lbName && item.Metadata.Name != "public-apiserver" {
 				return true, item.Metadata.ResourceID
 			}
 		}
 	}
+	if err := it.Err(); err != nil {
+		tflog.Error(ctx, "Error checking if IKS Load Balancer exists", map[string]any{"error": err.Error()})
+	}
 
 	return false, ""
 }
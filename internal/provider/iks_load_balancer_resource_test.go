@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"terraform-provider-intelcloud/internal/models"
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testIKSLoadBalancer(name, schema string, ports ...int64) models.IKSLoadBalancer {
+	lb := models.IKSLoadBalancer{
+		Name:   types.StringValue(name),
+		Schema: types.StringValue(schema),
+	}
+	for _, port := range ports {
+		lb.Listeners = append(lb.Listeners, models.IKSLoadBalancerListenerModel{
+			Port:     types.Int64Value(port),
+			Protocol: types.StringValue("TCP"),
+			Pool: models.IKSLoadBalancerPoolModel{
+				Port:          types.Int64Value(port),
+				NodeGroupName: types.StringValue("workers"),
+			},
+		})
+	}
+	return lb
+}
+
+func TestIKSLoadBalancerResource_CreateSendsOneRequestPerLoadBalancer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// load balancer reads go through the real http client
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"metadata": {"resourceId": "lb-1"}, "status": {"state": "Active"}}`))
+	}))
+	defer server.Close()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+	mockAPI.EXPECT().ParseString(gomock.Any(), gomock.Any()).Return(server.URL, nil).AnyTimes()
+
+	posts := map[string][]itacservices.IKSLoadBalancerListener{}
+	mockAPI.EXPECT().
+		MakePOSTAPICall(gomock.Any(), server.URL, "token", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, payload []byte) (int, []byte, error) {
+			var sent itacservices.IKSLoadbalancerCreateRequest
+			if err := json.Unmarshal(payload, &sent); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := posts[sent.Metadata.Name]; ok {
+				t.Errorf("load balancer %s created twice", sent.Metadata.Name)
+			}
+			posts[sent.Metadata.Name] = sent.Spec.Listeners
+			return http.StatusOK, []byte(`{"metadata": {"resourceId": "lb-1"}}`), nil
+		}).Times(2)
+
+	token := "token"
+	client := &itacservices.IDCServicesClient{
+		Host:         &server.URL,
+		Cloudaccount: &token,
+		Apitoken:     &token,
+		APIClient:    mockAPI,
+	}
+	for _, lb := range []models.IKSLoadBalancer{
+		testIKSLoadBalancer("web", "public", 80, 443),
+		testIKSLoadBalancer("api", "private", 8080, 8443, 9090),
+	} {
+		if _, err := createIKSLoadBalancer(context.Background(), client, "iks-1", lb); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// every listener goes in the one create request of its load balancer
+	if len(posts["web"]) != 2 || len(posts["api"]) != 3 {
+		t.Errorf("expected 2 and 3 listeners, got %d and %d", len(posts["web"]), len(posts["api"]))
+	}
+}
+
+func TestDiffIKSLoadBalancers(t *testing.T) {
+	state := []models.IKSLoadBalancer{
+		testIKSLoadBalancer("web", "public", 80),
+		testIKSLoadBalancer("api", "private", 8080),
+		testIKSLoadBalancer("metrics", "private", 9090),
+		testIKSLoadBalancer("old", "private", 7070),
+	}
+	for i := range state {
+		state[i].ID = types.StringValue("id-" + state[i].Name.ValueString())
+	}
+
+	// reordered, with one listener changed, one schema changed, one dropped and one added
+	plan := []models.IKSLoadBalancer{
+		testIKSLoadBalancer("metrics", "public", 9090),
+		testIKSLoadBalancer("new", "public", 443),
+		testIKSLoadBalancer("api", "private", 8081),
+		testIKSLoadBalancer("web", "public", 80),
+	}
+
+	removed, changes := diffIKSLoadBalancers(state, plan)
+
+	if len(removed) != 1 || removed[0].ID.ValueString() != "id-old" {
+		t.Errorf("expected only old to be removed, got %v", removed)
+	}
+	want := map[string]int{
+		"metrics": iksLoadBalancerReplace,
+		"new":     iksLoadBalancerCreate,
+		"api":     iksLoadBalancerUpdate,
+		"web":     iksLoadBalancerUnchanged,
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d", len(want), len(changes))
+	}
+	for _, change := range changes {
+		name := change.planned.Name.ValueString()
+		if change.action != want[name] {
+			t.Errorf("load balancer %s: expected action %d, got %d", name, want[name], change.action)
+		}
+		if change.action != iksLoadBalancerCreate && change.existing.ID.ValueString() != "id-"+name {
+			t.Errorf("load balancer %s: matched state entry %s", name, change.existing.ID.ValueString())
+		}
+	}
+}
+
+func TestIKSLoadBalancerPoolTargets(t *testing.T) {
+	ctx := context.Background()
+	selectors := types.MapValueMust(types.StringType, map[string]attr.Value{"app": types.StringValue("web")})
+	instances := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("inst-1")})
+	nodeGroupIDs := map[string]string{"workers": "ng-1"}
+
+	for _, tc := range []struct {
+		name string
+		pool models.IKSLoadBalancerPoolModel
+		// the pool target sent to the API
+		sentSelectors map[string]string
+		sentInstances []string
+	}{
+		{
+			name:          "node group id",
+			pool:          models.IKSLoadBalancerPoolModel{NodeGroupId: types.StringValue("ng-1")},
+			sentSelectors: nil,
+		},
+		{
+			name:          "node group name",
+			pool:          models.IKSLoadBalancerPoolModel{NodeGroupName: types.StringValue("workers")},
+			sentSelectors: map[string]string{itacservices.IKSNodeGroupNameSelector: "workers"},
+		},
+		{
+			name:          "instance selectors",
+			pool:          models.IKSLoadBalancerPoolModel{InstanceSelectors: selectors},
+			sentSelectors: map[string]string{"app": "web"},
+		},
+		{
+			name:          "instance resource ids",
+			pool:          models.IKSLoadBalancerPoolModel{InstanceResourceIds: instances},
+			sentInstances: []string{"inst-1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.pool.Port = types.Int64Value(80)
+			lb := models.IKSLoadBalancer{Listeners: []models.IKSLoadBalancerListenerModel{{Pool: tc.pool}}}
+			listeners, diags := iksLoadBalancerListenersFromModel(ctx, lb)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			sent := listeners[0].Pool
+			if !reflect.DeepEqual(sent.InstanceSelectors, tc.sentSelectors) || !reflect.DeepEqual(sent.InstanceResourceIds, tc.sentInstances) {
+				t.Errorf("sent selectors %v and instances %v", sent.InstanceSelectors, sent.InstanceResourceIds)
+			}
+
+			// the API reports node group targets by name; they read back the way they were configured
+			read := itacservices.LoadBalancerPool{
+				Port:                80,
+				InstanceSelectors:   sent.InstanceSelectors,
+				InstanceResourceIds: sent.InstanceResourceIds,
+			}
+			if !tc.pool.NodeGroupId.IsNull() {
+				read.InstanceSelectors = map[string]string{itacservices.IKSNodeGroupNameSelector: "workers"}
+			}
+			model := iksLoadBalancerPoolModelFromAPI(read, &tc.pool, nodeGroupIDs)
+			for name, values := range map[string][2]attr.Value{
+				"node_group_id":         {model.NodeGroupId, tc.pool.NodeGroupId},
+				"node_group_name":       {model.NodeGroupName, tc.pool.NodeGroupName},
+				"instance_selectors":    {model.InstanceSelectors, tc.pool.InstanceSelectors},
+				"instance_resource_ids": {model.InstanceResourceIds, tc.pool.InstanceResourceIds},
+			} {
+				if values[0].IsNull() != values[1].IsNull() || !values[1].IsNull() && !values[0].Equal(values[1]) {
+					t.Errorf("%s read back as %v, configured %v", name, values[0], values[1])
+				}
+			}
+		})
+	}
+}
+
+func TestIKSLoadBalancerIDModifier(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	(&iksLBResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	lb := func(name, schema string, id types.String) models.IKSLoadBalancer {
+		lb := testIKSLoadBalancer(name, schema)
+		lb.ID = id
+		lb.ListenerStatus = types.ListNull(types.ObjectType{AttrTypes: models.LoadBalancerListenerStatusAttributes})
+		return lb
+	}
+	model := func(lbs ...models.IKSLoadBalancer) *iksLoadBalancerResourceModel {
+		return &iksLoadBalancerResourceModel{ClusterUUID: types.StringValue("iks-1"), LoadBalancers: lbs}
+	}
+	unknown := types.StringUnknown()
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, model(
+		lb("web", "public", types.StringValue("lb-web")),
+		lb("api", "private", types.StringValue("lb-api")),
+	)); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	tests := []struct {
+		name string
+		plan *iksLoadBalancerResourceModel
+		want []types.String
+	}{
+		{"reordered", model(lb("api", "private", unknown), lb("web", "public", unknown)),
+			[]types.String{types.StringValue("lb-api"), types.StringValue("lb-web")}},
+		{"first removed", model(lb("api", "private", unknown)),
+			[]types.String{types.StringValue("lb-api")}},
+		{"schema changed", model(lb("web", "private", unknown), lb("api", "private", unknown)),
+			[]types.String{unknown, types.StringValue("lb-api")}},
+		{"added", model(lb("db", "private", unknown), lb("web", "public", unknown)),
+			[]types.String{unknown, types.StringValue("lb-web")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			if diags := plan.Set(ctx, tt.plan); diags.HasError() {
+				t.Fatal(diags)
+			}
+			for i, want := range tt.want {
+				req := planmodifier.StringRequest{
+					Path:      path.Root("load_balancers").AtListIndex(i).AtName("id"),
+					Plan:      plan,
+					State:     state,
+					PlanValue: unknown,
+				}
+				resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+				iksLoadBalancerIDModifier{}.PlanModifyString(ctx, req, resp)
+				if resp.Diagnostics.HasError() {
+					t.Fatal(resp.Diagnostics)
+				}
+				if !resp.PlanValue.Equal(want) {
+					t.Errorf("load balancer %d: expected id %v, got %v", i, want, resp.PlanValue)
+				}
+			}
+		})
+	}
+}
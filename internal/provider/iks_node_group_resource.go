@@ -3,13 +3,17 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"terraform-provider-intelcloud/internal/models"
 	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -20,23 +24,36 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &iksNodeGroupResource{}
-	_ resource.ResourceWithConfigure = &iksNodeGroupResource{}
+	_ resource.Resource                   = &iksNodeGroupResource{}
+	_ resource.ResourceWithConfigure      = &iksNodeGroupResource{}
+	_ resource.ResourceWithImportState    = &iksNodeGroupResource{}
+	_ resource.ResourceWithValidateConfig = &iksNodeGroupResource{}
 )
 
 // iksNodeGroupResourceModel maps the resource schema data.
 type iksNodeGroupResourceModel struct {
-	ClusterUUID       types.String   `tfsdk:"cluster_uuid"`
-	ID                types.String   `tfsdk:"id"`
-	Count             types.Int64    `tfsdk:"node_count"`
-	Name              types.String   `tfsdk:"name"`
-	NodeType          types.String   `tfsdk:"node_type"`
-	IMIId             types.String   `tfsdk:"imiid"`
-	State             types.String   `tfsdk:"state"`
-	UserDataURL       types.String   `tfsdk:"userdata_url"`
-	SSHPublicKeyNames []types.String `tfsdk:"ssh_public_key_names"`
-	Vnets             types.List     `tfsdk:"vnets"`
-	Timeouts          *timeoutsModel `tfsdk:"timeouts"`
+	ClusterUUID       types.String            `tfsdk:"cluster_uuid"`
+	Cloudaccount      types.String            `tfsdk:"cloudaccount"`
+	Region            types.String            `tfsdk:"region"`
+	ID                types.String            `tfsdk:"id"`
+	Count             types.Int64             `tfsdk:"node_count"`
+	AutoscaleEnabled  types.Bool              `tfsdk:"autoscaling_enabled"`
+	MinCount          types.Int64             `tfsdk:"min_count"`
+	MaxCount          types.Int64             `tfsdk:"max_count"`
+	Name              types.String            `tfsdk:"name"`
+	NodeType          types.String            `tfsdk:"node_type"`
+	IMIId             types.String            `tfsdk:"imiid"`
+	UpgradeAvailable  types.Bool              `tfsdk:"upgrade_available"`
+	UpgradeIMIId      types.String            `tfsdk:"upgrade_imiid"`
+	State             types.String            `tfsdk:"state"`
+	UserDataURL       types.String            `tfsdk:"userdata_url"`
+	SSHPublicKeyNames []types.String          `tfsdk:"ssh_public_key_names"`
+	Labels            types.Map               `tfsdk:"labels"`
+	Annotations       types.Map               `tfsdk:"annotations"`
+	Taints            []models.NodeGroupTaint `tfsdk:"taints"`
+	Vnets             types.List              `tfsdk:"vnets"`
+	ZoneNodeCounts    types.Map               `tfsdk:"zone_node_counts"`
+	Timeouts          *timeoutsModel          `tfsdk:"timeouts"`
 }
 
 // NewOrderKubernetes is a helper function to simplify the provider implementation.
@@ -80,21 +97,69 @@ func (r *iksNodeGroupResource) Schema(_ context.Context, _ resource.SchemaReques
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"cluster_uuid": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
+			"cloudaccount": cloudaccountAttribute(),
+			"region":       regionAttribute(),
 			"node_count": schema.Int64Attribute{
-				Required: true,
+				Required:    true,
+				Description: "Number of nodes. With autoscaling enabled this is the initial size and drift caused by the autoscaler is ignored.",
+			},
+			"autoscaling_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Let the IKS cluster autoscaler resize the node group between min_count and max_count.",
+			},
+			"min_count": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Minimum number of nodes kept by the autoscaler. Required when autoscaling is enabled.",
+			},
+			"max_count": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of nodes the autoscaler may scale to. Required when autoscaling is enabled.",
 			},
 			"node_type": schema.StringAttribute{
-				Required: true,
+				Required:    true,
+				Description: "Instance type of the nodes. Changing this forces a new node group.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"imiid": schema.StringAttribute{
-				Computed: true,
+				Optional:    true,
+				Computed:    true,
+				Description: "Node image of the node group. Set it to the value of upgrade_imiid to upgrade the nodes in place.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_node_counts": schema.MapAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "Number of nodes per availability zone.",
+			},
+			"upgrade_available": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the cluster reports a newer node image for this node group.",
+			},
+			"upgrade_imiid": schema.StringAttribute{
+				Computed:    true,
+				Description: "Node image the node group can be upgraded to.",
 			},
 			"state": schema.StringAttribute{
 				Computed: true,
@@ -102,26 +167,60 @@ func (r *iksNodeGroupResource) Schema(_ context.Context, _ resource.SchemaReques
 			"userdata_url": schema.StringAttribute{
 				Computed: true,
 				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"ssh_public_key_names": schema.SetAttribute{
 				ElementType: types.StringType,
 				Required:    true,
 			},
+			"labels": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Kubernetes labels applied to every node of the node group.",
+			},
+			"annotations": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Kubernetes annotations applied to every node of the node group.",
+			},
+			"taints": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "Kubernetes taints applied to every node of the node group.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Required: true,
+						},
+						"value": schema.StringAttribute{
+							Optional: true,
+						},
+						"effect": schema.StringAttribute{
+							Required:    true,
+							Description: "One of NoSchedule, PreferNoSchedule or NoExecute.",
+						},
+					},
+				},
+			},
 			"vnets": schema.ListNestedAttribute{
-				Optional: true,
-				Computed: true,
+				Optional:    true,
+				Computed:    true,
+				Description: "Placement of the node group, one entry per availability zone of the provider region. A zone without a vnet name uses the zone's default vnet, which is created if missing. Defaults to a single zone.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"availabilityzonename": schema.StringAttribute{
-							Optional: true,
-							Computed: true,
+							Optional:    true,
+							Computed:    true,
+							Description: "Availability zone, e.g. `us-region-1a`. Defaults to the zone of the vnet, or the first zone of the region.",
 							PlanModifiers: []planmodifier.String{
 								stringplanmodifier.UseStateForUnknown(),
 							},
 						},
 						"networkinterfacevnetname": schema.StringAttribute{
-							Optional: true,
-							Computed: true,
+							Optional:    true,
+							Computed:    true,
+							Description: "Existing vnet to place the nodes in.",
 							PlanModifiers: []planmodifier.String{
 								stringplanmodifier.UseStateForUnknown(),
 							},
@@ -130,6 +229,9 @@ func (r *iksNodeGroupResource) Schema(_ context.Context, _ resource.SchemaReques
 				},
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
+					listplanmodifier.RequiresReplaceIf(nodeGroupVnetsRequireReplace,
+						"Changing the configured placement forces a new node group.",
+						"Changing the configured placement forces a new node group."),
 				},
 			},
 		},
@@ -140,7 +242,7 @@ func (r *iksNodeGroupResource) Schema(_ context.Context, _ resource.SchemaReques
 						Optional:    true,
 						Computed:    true,
 						Description: "Timeout for nodegroup resource operations",
-						Default:     stringdefault.StaticString(IKSNodegroupResourceName),
+						Default:     stringdefault.StaticString(IKSNodegroupResourceTimeout),
 					},
 				},
 			},
@@ -148,6 +250,120 @@ func (r *iksNodeGroupResource) Schema(_ context.Context, _ resource.SchemaReques
 	}
 }
 
+// nodeGroupVnetsRequireReplace replaces the node group when the configured placement differs
+// from the state. Only the configured fields of each entry are compared, the computed ones
+// are still unknown when the list is planned.
+func nodeGroupVnetsRequireReplace(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+	if req.ConfigValue.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	config, state := []models.NetworkInterfaceSpec{}, []models.NetworkInterfaceSpec{}
+	resp.Diagnostics.Append(req.ConfigValue.ElementsAs(ctx, &config, false)...)
+	resp.Diagnostics.Append(req.StateValue.ElementsAs(ctx, &state, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(config) != len(state) {
+		resp.RequiresReplace = true
+		return
+	}
+	for i, spec := range config {
+		if configuredChange(spec.AvailabilityZoneName, state[i].AvailabilityZoneName) ||
+			configuredChange(spec.NetworkInterfaceVnetName, state[i].NetworkInterfaceVnetName) {
+			resp.RequiresReplace = true
+			return
+		}
+	}
+}
+
+// configuredChange reports whether a configured value differs from the state one.
+func configuredChange(config, state types.String) bool {
+	return !config.IsNull() && !config.Equal(state)
+}
+
+var nodeGroupTaintEffects = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+
+// ValidateConfig checks placement zones, taint effects and that the autoscaling bounds are consistent with the node count.
+func (r *iksNodeGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config iksNodeGroupResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Vnets.IsNull() && !config.Vnets.IsUnknown() {
+		specs := []models.NetworkInterfaceSpec{}
+		resp.Diagnostics.Append(config.Vnets.ElementsAs(ctx, &specs, false)...)
+		zones := map[string]bool{}
+		for i, spec := range specs {
+			if spec.AvailabilityZoneName.IsNull() || spec.AvailabilityZoneName.IsUnknown() {
+				continue
+			}
+			zone := spec.AvailabilityZoneName.ValueString()
+			if zones[zone] {
+				resp.Diagnostics.AddAttributeError(path.Root("vnets").AtListIndex(i).AtName("availabilityzonename"),
+					"Invalid node group placement",
+					fmt.Sprintf("availability zone %s is listed more than once", zone))
+			}
+			zones[zone] = true
+		}
+	}
+
+	for i, t := range config.Taints {
+		if t.Effect.IsUnknown() || t.Effect.IsNull() {
+			continue
+		}
+		if !slices.Contains(nodeGroupTaintEffects, t.Effect.ValueString()) {
+			resp.Diagnostics.AddAttributeError(path.Root("taints").AtListIndex(i).AtName("effect"),
+				"Invalid taint effect",
+				fmt.Sprintf("taint effect must be one of %s, got %q",
+					strings.Join(nodeGroupTaintEffects, ", "), t.Effect.ValueString()))
+		}
+	}
+
+	if config.AutoscaleEnabled.IsUnknown() || config.MinCount.IsUnknown() ||
+		config.MaxCount.IsUnknown() || config.Count.IsUnknown() {
+		return
+	}
+
+	if !config.AutoscaleEnabled.ValueBool() {
+		if !config.MinCount.IsNull() || !config.MaxCount.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("autoscaling_enabled"),
+				"Invalid autoscaling configuration",
+				"min_count and max_count can only be set when autoscaling_enabled is true")
+		}
+		return
+	}
+
+	if config.MinCount.IsNull() || config.MaxCount.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("autoscaling_enabled"),
+			"Invalid autoscaling configuration",
+			"min_count and max_count are required when autoscaling_enabled is true")
+		return
+	}
+
+	minCount, maxCount := config.MinCount.ValueInt64(), config.MaxCount.ValueInt64()
+	if minCount < 0 || minCount > maxCount {
+		resp.Diagnostics.AddAttributeError(path.Root("min_count"),
+			"Invalid autoscaling configuration",
+			fmt.Sprintf("min_count (%d) must be between 0 and max_count (%d)", minCount, maxCount))
+		return
+	}
+	if !config.Count.IsNull() && (config.Count.ValueInt64() < minCount || config.Count.ValueInt64() > maxCount) {
+		resp.Diagnostics.AddAttributeError(path.Root("node_count"),
+			"Invalid autoscaling configuration",
+			fmt.Sprintf("node_count (%d) must be between min_count (%d) and max_count (%d)",
+				config.Count.ValueInt64(), minCount, maxCount))
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *iksNodeGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -168,6 +384,14 @@ func (r *iksNodeGroupResource) Create(ctx context.Context, req resource.CreateRe
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Region = types.StringValue(*client.Region)
+	plan.Cloudaccount = types.StringValue(*client.Cloudaccount)
+
 	inArg := itacservices.IKSNodeGroupCreateRequest{
 		Name:           plan.Name.ValueString(),
 		Count:          plan.Count.ValueInt64(),
@@ -175,28 +399,26 @@ func (r *iksNodeGroupResource) Create(ctx context.Context, req resource.CreateRe
 		InstanceTypeId: plan.NodeType.ValueString(),
 		UserDataURL:    plan.UserDataURL.ValueString(),
 	}
+	if plan.AutoscaleEnabled.ValueBool() {
+		inArg.Autoscaling = nodeGroupAutoscalingFromModel(&plan)
+	}
+	inArg.Labels, inArg.Annotations, inArg.Taints, diags = nodeGroupKubernetesMetadataFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	for _, k := range plan.SSHPublicKeyNames {
 		inArg.SSHKeyNames = append(inArg.SSHKeyNames, itacservices.SKey{Name: k.ValueString()})
 	}
 
-	tflog.Info(ctx, "making a call to IDC Service to createVnetIfNotExist")
-	vnetResp, err := r.client.CreateVNetIfNotFound(ctx, *r.client.Region)
-	if err != nil || vnetResp == nil {
-		resp.Diagnostics.AddError(
-			"Error creating order",
-			"Could not create order, unexpected error: "+err.Error(),
-		)
+	inArg.Vnets, diags = resolveNodeGroupVnets(ctx, client, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	inArg.Vnets = append(inArg.Vnets,
-		itacservices.Vnet{
-			AvailabilityZoneName:     vnetResp.Spec.AvailabilityZone,
-			NetworkInterfaceVnetName: vnetResp.Metadata.Name,
-		})
-
-	nodeGroupResp, _, err := r.client.CreateIKSNodeGroup(ctx, &inArg, plan.ClusterUUID.ValueString(), false)
+	nodeGroupResp, _, err := client.CreateIKSNodeGroup(ctx, &inArg, plan.ClusterUUID.ValueString(), false)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating iks node group",
@@ -205,34 +427,21 @@ func (r *iksNodeGroupResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	plan.ClusterUUID = types.StringValue(nodeGroupResp.ClusterID)
-	plan.Name = types.StringValue(nodeGroupResp.Name)
-	plan.ID = types.StringValue(nodeGroupResp.ID)
-	plan.IMIId = types.StringValue(nodeGroupResp.IMIID)
-	plan.State = types.StringValue(nodeGroupResp.State)
-	plan.Count = types.Int64Value(nodeGroupResp.Count)
-	plan.NodeType = types.StringValue(nodeGroupResp.InstanceType)
-	plan.UserDataURL = types.StringValue(nodeGroupResp.UserDataURL)
-	vnets := []models.NetworkInterfaceSpec{}
-	for _, iface := range nodeGroupResp.Interfaces {
-		v := models.NetworkInterfaceSpec{
-			AvailabilityZoneName:     types.StringValue(iface.AvailabilityZoneName),
-			NetworkInterfaceVnetName: types.StringValue(iface.NetworkInterfaceVnetName),
-		}
-		vnets = append(vnets, v)
-	}
-
-	vnetObj, diags := types.ListValueFrom(ctx, types.ObjectType{}.WithAttributeTypes(models.VnetAttributes), vnets)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	currState, err := refreshIKSNodegroupResourceModel(ctx, nodeGroupResp, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading IKS nodegroup resource",
+			"Could not read IKS nodegroup resource ID "+nodeGroupResp.ID+": "+err.Error(),
+		)
 		return
 	}
-	plan.Vnets = vnetObj
-
-	plan.SSHPublicKeyNames = []types.String{}
-	for _, k := range nodeGroupResp.SSHKeyNames {
-		plan.SSHPublicKeyNames = append(plan.SSHPublicKeyNames, types.StringValue(k.Name))
+	currState.Timeouts = plan.Timeouts
+	currState.Cloudaccount = plan.Cloudaccount
+	currState.Region = plan.Region
+	if currState.AutoscaleEnabled.ValueBool() {
+		currState.Count = plan.Count
 	}
+	plan = *currState
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -262,8 +471,21 @@ func (r *iksNodeGroupResource) Read(ctx context.Context, req resource.ReadReques
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
 
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Region = types.StringValue(*client.Region)
+	state.Cloudaccount = types.StringValue(*client.Cloudaccount)
+
 	// Get refreshed order value from IDC Service
-	ngState, err := r.client.GetIKSNodeGroupByID(ctx, state.ClusterUUID.ValueString(), state.ID.ValueString())
+	ngState, err := client.GetIKSNodeGroupByID(ctx, state.ClusterUUID.ValueString(), state.ID.ValueString())
+	if common.IsNotFound(err) {
+		tflog.Warn(ctx, "iks node group not found, removing from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading IDC Compute IKS Node Group resource",
@@ -272,35 +494,22 @@ func (r *iksNodeGroupResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	state.ClusterUUID = types.StringValue(ngState.ClusterID)
-	state.Name = types.StringValue(ngState.Name)
-	state.ID = types.StringValue(ngState.ID)
-	state.IMIId = types.StringValue(ngState.IMIID)
-	state.State = types.StringValue(ngState.State)
-	state.Count = types.Int64Value(ngState.Count)
-	state.NodeType = types.StringValue(ngState.InstanceType)
-	state.UserDataURL = types.StringValue(ngState.UserDataURL)
-
-	vnets := []models.NetworkInterfaceSpec{}
-	for _, i := range ngState.Interfaces {
-		v := models.NetworkInterfaceSpec{
-			AvailabilityZoneName:     types.StringValue(i.AvailabilityZoneName),
-			NetworkInterfaceVnetName: types.StringValue(i.NetworkInterfaceVnetName),
-		}
-		vnets = append(vnets, v)
-	}
-
-	vnetObj, diags := types.ListValueFrom(ctx, types.ObjectType{}.WithAttributeTypes(models.VnetAttributes), vnets)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	currState, err := refreshIKSNodegroupResourceModel(ctx, ngState, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading IKS nodegroup resource",
+			"Could not read IKS nodegroup resource ID "+state.ID.ValueString()+": "+err.Error(),
+		)
 		return
 	}
-	state.Vnets = vnetObj
-
-	state.SSHPublicKeyNames = []types.String{}
-	for _, k := range ngState.SSHKeyNames {
-		state.SSHPublicKeyNames = append(state.SSHPublicKeyNames, types.StringValue(k.Name))
+	currState.Timeouts = state.Timeouts
+	currState.Cloudaccount = state.Cloudaccount
+	currState.Region = state.Region
+	// the autoscaler owns the node count, do not report its changes as drift
+	if currState.AutoscaleEnabled.ValueBool() && !state.Count.IsNull() {
+		currState.Count = state.Count
 	}
+	state = *currState
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &state)
@@ -338,15 +547,46 @@ func (r *iksNodeGroupResource) Update(ctx context.Context, req resource.UpdateRe
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
 
-	if !plan.Count.Equal(state.Count) {
-		tflog.Info(ctx, "Detected change in iks node group spec for count, updating node group",
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Region = types.StringValue(*client.Region)
+	plan.Cloudaccount = types.StringValue(*client.Cloudaccount)
+
+	userDataURL := plan.UserDataURL
+	if userDataURL.IsUnknown() {
+		userDataURL = state.UserDataURL
+	}
+
+	if !plan.Count.Equal(state.Count) ||
+		!equalStringSets(plan.SSHPublicKeyNames, state.SSHPublicKeyNames) ||
+		!userDataURL.Equal(state.UserDataURL) ||
+		!plan.AutoscaleEnabled.Equal(state.AutoscaleEnabled) ||
+		!plan.MinCount.Equal(state.MinCount) ||
+		!plan.MaxCount.Equal(state.MaxCount) ||
+		!plan.Labels.Equal(state.Labels) ||
+		!plan.Annotations.Equal(state.Annotations) ||
+		!slices.Equal(plan.Taints, state.Taints) {
+		tflog.Info(ctx, "Detected change in iks node group spec, updating node group",
 			map[string]any{"current count ": state.Count.ValueInt64(), "new count": plan.Count.ValueInt64()})
 		inArg := itacservices.UpdateNodeGroupRequest{
 			ClusterId:   state.ClusterUUID.ValueString(),
 			NodeGroupId: state.ID.ValueString(),
 			Count:       plan.Count.ValueInt64(),
+			UserDataURL: userDataURL.ValueString(),
+			Autoscaling: nodeGroupAutoscalingFromModel(&plan),
+		}
+		for _, k := range plan.SSHPublicKeyNames {
+			inArg.SSHKeyNames = append(inArg.SSHKeyNames, itacservices.SKey{Name: k.ValueString()})
+		}
+		inArg.Labels, inArg.Annotations, inArg.Taints, diags = nodeGroupKubernetesMetadataFromModel(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
-		err := r.client.UpdateNodeGroup(ctx, &inArg)
+		err := client.UpdateNodeGroup(ctx, &inArg)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error updating node group order",
@@ -356,8 +596,26 @@ func (r *iksNodeGroupResource) Update(ctx context.Context, req resource.UpdateRe
 		}
 	}
 
+	if !plan.IMIId.IsUnknown() && !plan.IMIId.IsNull() && !plan.IMIId.Equal(state.IMIId) {
+		tflog.Info(ctx, "Detected change in iks node group image, upgrading node group",
+			map[string]any{"current imiid": state.IMIId.ValueString(), "new imiid": plan.IMIId.ValueString()})
+		inArg := itacservices.UpgradeNodeGroupRequest{
+			ClusterId:   state.ClusterUUID.ValueString(),
+			NodeGroupId: state.ID.ValueString(),
+			IMIID:       plan.IMIId.ValueString(),
+		}
+		err := client.UpgradeNodeGroup(ctx, &inArg)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error upgrading node group image",
+				"Could not upgrade nodegroup image, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	// Get refreshed order value from IDC Service irrespective of whether upgrade was done or skipped
-	nodeGroup, err := r.client.GetIKSNodeGroupByID(ctx, state.ClusterUUID.ValueString(), state.ID.ValueString())
+	nodeGroup, err := client.GetIKSNodeGroupByID(ctx, state.ClusterUUID.ValueString(), state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading IKS nodegroup resource",
@@ -366,7 +624,7 @@ func (r *iksNodeGroupResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	currState, err := refreshIKSNodegroupResourceModel(ctx, nodeGroup)
+	currState, err := refreshIKSNodegroupResourceModel(ctx, nodeGroup, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading IKS cluster resource",
@@ -376,6 +634,11 @@ func (r *iksNodeGroupResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 	// set timeout again for consistency
 	currState.Timeouts = plan.Timeouts
+	currState.Cloudaccount = plan.Cloudaccount
+	currState.Region = plan.Region
+	if currState.AutoscaleEnabled.ValueBool() {
+		currState.Count = plan.Count
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, currState)
@@ -386,24 +649,50 @@ func (r *iksNodeGroupResource) Update(ctx context.Context, req resource.UpdateRe
 }
 
 func (r *iksNodeGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	// Expect import ID in the format: cluster_id:id
-	ids := strings.Split(req.ID, ":")
-	if len(ids) != 2 {
+	scope, diags := resolveImportScope(ctx, r.client, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Expect import ID in the format: cluster:nodegroup, each given by ID or by name
+	ids := strings.Split(scope.id, ":")
+	if len(ids) != 2 || ids[0] == "" || ids[1] == "" {
 		resp.Diagnostics.AddError(
 			"Invalid import format",
-			"Expected import ID in the format 'cluster_id:id'. Example: abc123:def456",
+			"Expected import ID in the format 'cluster:nodegroup', each given by ID or name. Example: abc123:def456",
 		)
 		return
 	}
 
-	clusterID := ids[0]
-	nodegroupId := ids[1]
+	clusterID, err := resolveIKSClusterImportID(ctx, scope.client, ids[0])
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import IKS node group", err.Error())
+		return
+	}
+
+	cluster, _, err := scope.client.GetIKSClusterByClusterUUID(ctx, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to import IKS node group",
+			"Could not read IKS cluster "+clusterID+": "+err.Error(),
+		)
+		return
+	}
+	candidates := []importCandidate{}
+	for _, ng := range cluster.NodeGroups {
+		candidates = append(candidates, importCandidate{ID: ng.ID, Name: ng.Name})
+	}
+	nodegroupId, err := resolveImportID("iks node group", ids[1], candidates)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import IKS node group", err.Error())
+		return
+	}
 
 	// Set both attributes in state
-	resp.State.SetAttribute(ctx, path.Root("cluster_uuid"), clusterID)
-	resp.State.SetAttribute(ctx, path.Root("id"), nodegroupId)
-	//resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(scope.setState(ctx, &resp.State, path.Root("cloudaccount"))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_uuid"), clusterID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), nodegroupId)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -426,8 +715,14 @@ func (r *iksNodeGroupResource) Delete(ctx context.Context, req resource.DeleteRe
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
 
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the order from IDC Services
-	err = r.client.DeleteIKSNodeGroup(ctx, state.ClusterUUID.ValueString(), state.ID.ValueString())
+	err = client.DeleteIKSNodeGroup(ctx, state.ClusterUUID.ValueString(), state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting IDC IKS node group resource",
@@ -435,23 +730,193 @@ func (r *iksNodeGroupResource) Delete(ctx context.Context, req resource.DeleteRe
 		)
 		return
 	}
+	if err := client.WaitForIKSNodeGroupDeleted(ctx, state.ClusterUUID.ValueString(), state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting IDC IKS node group resource",
+			"Could not delete IDC IKS node group resource ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+	}
 }
 
-func refreshIKSNodegroupResourceModel(ctx context.Context, nodegroup *itacservices.NodeGroup) (*iksNodeGroupResourceModel, error) {
+// resolveNodeGroupVnets maps the requested placement to vnets. Zones are validated against the
+// region of client and zones without a vnet name get their default vnet, created when missing.
+func resolveNodeGroupVnets(ctx context.Context, client *itacservices.IDCServicesClient, plan *iksNodeGroupResourceModel) ([]itacservices.Vnet, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	region := *client.Region
+
+	specs := []models.NetworkInterfaceSpec{}
+	if !plan.Vnets.IsNull() && !plan.Vnets.IsUnknown() {
+		diags.Append(plan.Vnets.ElementsAs(ctx, &specs, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	if len(specs) == 0 {
+		tflog.Info(ctx, "making a call to IDC Service to createVnetIfNotExist")
+		vnetResp, err := client.CreateVNetIfNotFound(ctx, region)
+		if err != nil {
+			diags.AddError(
+				"Error creating order",
+				"Could not create order, unexpected error: "+err.Error(),
+			)
+			return nil, diags
+		}
+		return []itacservices.Vnet{{
+			AvailabilityZoneName:     vnetResp.Spec.AvailabilityZone,
+			NetworkInterfaceVnetName: vnetResp.Metadata.Name,
+		}}, diags
+	}
+
+	regionInfo, err := client.RegionInfo()
+	if err != nil {
+		diags.AddAttributeError(path.Root("vnets"), "Invalid node group placement", err.Error())
+		return nil, diags
+	}
+
+	vnets := []itacservices.Vnet{}
+	for i, spec := range specs {
+		specPath := path.Root("vnets").AtListIndex(i)
+		zone := spec.AvailabilityZoneName.ValueString()
+		vnetName := spec.NetworkInterfaceVnetName.ValueString()
+
+		if zone != "" {
+			if err := regionInfo.ValidateZone(zone); err != nil {
+				diags.AddAttributeError(specPath.AtName("availabilityzonename"), "Invalid node group placement", err.Error())
+				continue
+			}
+		}
+
+		if vnetName == "" {
+			if zone == "" {
+				zone = regionInfo.DefaultZone()
+			}
+			tflog.Info(ctx, "resolving vnet for node group zone", map[string]any{"availabilityZone": zone})
+			vnet, err := client.CreateVNetInZoneIfNotFound(ctx, region, zone)
+			if err != nil {
+				diags.AddAttributeError(specPath, "Error creating vnet",
+					"Could not create vnet in availability zone "+zone+", unexpected error: "+err.Error())
+				continue
+			}
+			vnetName = vnet.Metadata.Name
+		} else {
+			vnet, err := client.GetVNetByName(ctx, vnetName)
+			if err != nil {
+				diags.AddAttributeError(specPath.AtName("networkinterfacevnetname"), "Invalid node group placement", err.Error())
+				continue
+			}
+			if zone == "" {
+				zone = vnet.Spec.AvailabilityZone
+			} else if zone != vnet.Spec.AvailabilityZone {
+				diags.AddAttributeError(specPath, "Invalid node group placement",
+					fmt.Sprintf("vnet %s is in availability zone %s, not %s", vnetName, vnet.Spec.AvailabilityZone, zone))
+				continue
+			}
+			if err := regionInfo.ValidateZone(zone); err != nil {
+				diags.AddAttributeError(specPath.AtName("networkinterfacevnetname"), "Invalid node group placement", err.Error())
+				continue
+			}
+		}
+
+		vnets = append(vnets, itacservices.Vnet{
+			AvailabilityZoneName:     zone,
+			NetworkInterfaceVnetName: vnetName,
+		})
+	}
+	return vnets, diags
+}
+
+// nodeGroupAutoscalingFromModel maps the autoscaling attributes to the IKS autoscaler settings.
+func nodeGroupAutoscalingFromModel(m *iksNodeGroupResourceModel) *itacservices.NodeGroupAutoscaling {
+	if !m.AutoscaleEnabled.ValueBool() {
+		return &itacservices.NodeGroupAutoscaling{Enabled: false}
+	}
+	return &itacservices.NodeGroupAutoscaling{
+		Enabled:  true,
+		MinCount: m.MinCount.ValueInt64(),
+		MaxCount: m.MaxCount.ValueInt64(),
+	}
+}
+
+// nodeGroupKubernetesMetadataFromModel converts the labels, annotations and taints to their API form.
+func nodeGroupKubernetesMetadataFromModel(ctx context.Context, m *iksNodeGroupResourceModel) (map[string]string, map[string]string, []itacservices.Taint, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	labels := map[string]string{}
+	annotations := map[string]string{}
+	taints := []itacservices.Taint{}
+
+	if !m.Labels.IsNull() && !m.Labels.IsUnknown() {
+		diags.Append(m.Labels.ElementsAs(ctx, &labels, false)...)
+	}
+	if !m.Annotations.IsNull() && !m.Annotations.IsUnknown() {
+		diags.Append(m.Annotations.ElementsAs(ctx, &annotations, false)...)
+	}
+	for _, t := range m.Taints {
+		taints = append(taints, itacservices.Taint{
+			Key:    t.Key.ValueString(),
+			Value:  t.Value.ValueString(),
+			Effect: t.Effect.ValueString(),
+		})
+	}
+	return labels, annotations, taints, diags
+}
+
+// refreshIKSNodegroupResourceModel builds the model from the node group read from the API.
+// The API drops empty labels, annotations and taint values, so they stay empty rather than
+// null where prior, the plan or state the read follows, has them set.
+func refreshIKSNodegroupResourceModel(ctx context.Context, nodegroup *itacservices.NodeGroup, prior *iksNodeGroupResourceModel) (*iksNodeGroupResourceModel, error) {
 	state := &iksNodeGroupResourceModel{}
 
 	state.ID = types.StringValue(nodegroup.ID)
 	state.ClusterUUID = types.StringValue(nodegroup.ClusterID)
 	state.Name = types.StringValue(nodegroup.Name)
 	state.Count = types.Int64Value(nodegroup.Count)
+	state.AutoscaleEnabled = types.BoolValue(false)
+	state.MinCount = types.Int64Null()
+	state.MaxCount = types.Int64Null()
+	if nodegroup.Autoscaling != nil && nodegroup.Autoscaling.Enabled {
+		state.AutoscaleEnabled = types.BoolValue(true)
+		state.MinCount = types.Int64Value(nodegroup.Autoscaling.MinCount)
+		state.MaxCount = types.Int64Value(nodegroup.Autoscaling.MaxCount)
+	}
 	state.State = types.StringValue(nodegroup.State)
 	state.IMIId = types.StringValue(nodegroup.IMIID)
+	state.UpgradeAvailable = types.BoolValue(nodegroup.UpgradeAvailable)
+	state.UpgradeIMIId = types.StringValue(nodegroup.UpgradeIMIID)
 	state.UserDataURL = types.StringValue(nodegroup.UserDataURL)
 	state.NodeType = types.StringValue(nodegroup.InstanceType)
 	state.SSHPublicKeyNames = []types.String{}
 	for _, k := range nodegroup.SSHKeyNames {
 		state.SSHPublicKeyNames = append(state.SSHPublicKeyNames, types.StringValue(k.Name))
 	}
+	state.Labels = types.MapNull(types.StringType)
+	if len(nodegroup.Labels) > 0 || prior != nil && !prior.Labels.IsNull() && !prior.Labels.IsUnknown() {
+		labels, diags := types.MapValueFrom(ctx, types.StringType, nonNilMap(nodegroup.Labels))
+		if diags.HasError() {
+			return state, fmt.Errorf("error parsing labels")
+		}
+		state.Labels = labels
+	}
+	state.Annotations = types.MapNull(types.StringType)
+	if len(nodegroup.Annotations) > 0 || prior != nil && !prior.Annotations.IsNull() && !prior.Annotations.IsUnknown() {
+		annotations, diags := types.MapValueFrom(ctx, types.StringType, nonNilMap(nodegroup.Annotations))
+		if diags.HasError() {
+			return state, fmt.Errorf("error parsing annotations")
+		}
+		state.Annotations = annotations
+	}
+	for _, t := range nodegroup.Taints {
+		taint := models.NodeGroupTaint{
+			Key:    types.StringValue(t.Key),
+			Value:  types.StringNull(),
+			Effect: types.StringValue(t.Effect),
+		}
+		if t.Value != "" || priorTaintHasValue(prior, t) {
+			taint.Value = types.StringValue(t.Value)
+		}
+		state.Taints = append(state.Taints, taint)
+	}
+
 	vnets := []models.NetworkInterfaceSpec{}
 	for _, iface := range nodegroup.Interfaces {
 		v := models.NetworkInterfaceSpec{
@@ -465,9 +930,48 @@ func refreshIKSNodegroupResourceModel(ctx context.Context, nodegroup *itacservic
 		return state, fmt.Errorf("error parsing values")
 	}
 	state.Vnets = vnetObj
+
+	zoneNodeCounts := map[string]int64{}
+	for _, iface := range nodegroup.Interfaces {
+		zoneNodeCounts[iface.AvailabilityZoneName] = 0
+	}
+	if len(nodegroup.Nodes) == 0 && len(nodegroup.Interfaces) == 1 {
+		zoneNodeCounts[nodegroup.Interfaces[0].AvailabilityZoneName] = nodegroup.Count
+	}
+	for _, node := range nodegroup.Nodes {
+		if node.AvailabilityZone != "" {
+			zoneNodeCounts[node.AvailabilityZone]++
+		} else if len(nodegroup.Interfaces) == 1 {
+			zoneNodeCounts[nodegroup.Interfaces[0].AvailabilityZoneName]++
+		}
+	}
+	countsObj, diags := types.MapValueFrom(ctx, types.Int64Type, zoneNodeCounts)
 	if diags.HasError() {
-		return state, fmt.Errorf("error parsing values")
+		return state, fmt.Errorf("error parsing zone node counts")
 	}
+	state.ZoneNodeCounts = countsObj
 
 	return state, nil
 }
+
+// priorTaintHasValue reports whether prior sets a value, possibly empty, on the taint with the
+// same key and effect as t.
+func priorTaintHasValue(prior *iksNodeGroupResourceModel, t itacservices.Taint) bool {
+	if prior == nil {
+		return false
+	}
+	for _, p := range prior.Taints {
+		if p.Key.ValueString() == t.Key && p.Effect.ValueString() == t.Effect {
+			return !p.Value.IsNull() && !p.Value.IsUnknown()
+		}
+	}
+	return false
+}
+
+// nonNilMap returns m, or an empty map when m is nil, which would otherwise convert to null.
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
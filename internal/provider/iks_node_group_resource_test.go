@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-intelcloud/internal/models"
+	"terraform-provider-intelcloud/pkg/itacservices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRefreshIKSNodegroupResourceModel_EmptyKubernetesMetadata(t *testing.T) {
+	ctx := context.Background()
+	nodegroup := &itacservices.NodeGroup{
+		ID:     "ng-1",
+		Taints: []itacservices.Taint{{Key: "dedicated", Effect: "NoSchedule"}},
+	}
+
+	// without prior values the empty metadata reads as null
+	state, err := refreshIKSNodegroupResourceModel(ctx, nodegroup, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Labels.IsNull() || !state.Annotations.IsNull() || !state.Taints[0].Value.IsNull() {
+		t.Errorf("expected null labels, annotations and taint value, got %v %v %v", state.Labels, state.Annotations, state.Taints[0].Value)
+	}
+
+	// configured empty values are kept empty
+	prior := &iksNodeGroupResourceModel{
+		Labels:      types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		Annotations: types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		Taints: []models.NodeGroupTaint{{
+			Key:    types.StringValue("dedicated"),
+			Value:  types.StringValue(""),
+			Effect: types.StringValue("NoSchedule"),
+		}},
+	}
+	state, err = refreshIKSNodegroupResourceModel(ctx, nodegroup, prior)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Labels.Equal(prior.Labels) || !state.Annotations.Equal(prior.Annotations) {
+		t.Errorf("expected empty labels and annotations, got %v %v", state.Labels, state.Annotations)
+	}
+	if !state.Taints[0].Value.Equal(types.StringValue("")) {
+		t.Errorf("expected empty taint value, got %v", state.Taints[0].Value)
+	}
+}
+
+func TestNodeGroupVnetsRequireReplace(t *testing.T) {
+	ctx := context.Background()
+	entry := func(zone, vnet types.String) attr.Value {
+		return types.ObjectValueMust(map[string]attr.Type{
+			"availabilityzonename":     types.StringType,
+			"networkinterfacevnetname": types.StringType,
+		}, map[string]attr.Value{
+			"availabilityzonename":     zone,
+			"networkinterfacevnetname": vnet,
+		})
+	}
+	list := func(entries ...attr.Value) types.List {
+		return types.ListValueMust(entry(types.StringNull(), types.StringNull()).Type(ctx), entries)
+	}
+	state := list(
+		entry(types.StringValue("us-region-1a"), types.StringValue("us-region-1a-default")),
+		entry(types.StringValue("us-region-1b"), types.StringValue("us-region-1b-default")),
+	)
+
+	tests := []struct {
+		name   string
+		config types.List
+		want   bool
+	}{
+		{"not configured", types.ListNull(entry(types.StringNull(), types.StringNull()).Type(ctx)), false},
+		{"zones only", list(
+			entry(types.StringValue("us-region-1a"), types.StringNull()),
+			entry(types.StringValue("us-region-1b"), types.StringNull()),
+		), false},
+		{"same vnets", list(
+			entry(types.StringValue("us-region-1a"), types.StringValue("us-region-1a-default")),
+			entry(types.StringNull(), types.StringValue("us-region-1b-default")),
+		), false},
+		{"other zone", list(
+			entry(types.StringValue("us-region-1a"), types.StringNull()),
+			entry(types.StringValue("us-region-1c"), types.StringNull()),
+		), true},
+		{"other vnet", list(
+			entry(types.StringNull(), types.StringValue("lab-vnet")),
+			entry(types.StringValue("us-region-1b"), types.StringNull()),
+		), true},
+		{"zone removed", list(
+			entry(types.StringValue("us-region-1a"), types.StringNull()),
+		), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &listplanmodifier.RequiresReplaceIfFuncResponse{}
+			nodeGroupVnetsRequireReplace(ctx, planmodifier.ListRequest{ConfigValue: tt.config, StateValue: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if resp.RequiresReplace != tt.want {
+				t.Errorf("expected requires replace %v, got %v", tt.want, resp.RequiresReplace)
+			}
+		})
+	}
+}
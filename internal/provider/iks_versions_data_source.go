@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"terraform-provider-intelcloud/internal/models"
+	"terraform-provider-intelcloud/pkg/itacservices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewIKSVersionsDataSource() datasource.DataSource {
+	return &iksVersionsDataSource{}
+}
+
+type iksVersionsDataSource struct {
+	client *itacservices.IDCServicesClient
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &iksVersionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &iksVersionsDataSource{}
+)
+
+// iksVersionsDataSourceModel maps the data source schema data.
+type iksVersionsDataSourceModel struct {
+	Filters  []KVFilter          `tfsdk:"filters"`
+	Versions []models.IKSVersion `tfsdk:"versions"`
+	Runtimes []types.String      `tfsdk:"runtimes"`
+	Latest   types.String        `tfsdk:"latest"`
+	Default  types.String        `tfsdk:"default"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *iksVersionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*itacservices.IDCServicesClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *itacservices.IDCServicesClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *iksVersionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iks_versions"
+}
+
+func (d *iksVersionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the Kubernetes versions and container runtimes supported by IKS.",
+		Attributes: map[string]schema.Attribute{
+			"filters": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "Filters applied to the version list. Supported names are `runtime` and `version` (prefix match, e.g. `1.30`).",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required: true,
+						},
+						"values": schema.ListAttribute{
+							ElementType: types.StringType,
+							Required:    true,
+						},
+					},
+				},
+			},
+			"versions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Supported versions, newest first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.StringAttribute{
+							Computed: true,
+						},
+						"runtime": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"runtimes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Distinct container runtimes of the matching versions.",
+			},
+			"latest": schema.StringAttribute{
+				Computed:    true,
+				Description: "Newest matching version for any runtime.",
+			},
+			"default": schema.StringAttribute{
+				Computed:    true,
+				Description: "Newest matching version for the runtime used by intelcloud_iks_cluster.",
+			},
+		},
+	}
+}
+
+func (d *iksVersionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state iksVersionsDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	k8sVersions, err := d.client.GetIKSK8sVersions(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read ITAC IKS Kubernetes versions",
+			err.Error(),
+		)
+		return
+	}
+
+	versions, err := filterIKSVersions(k8sVersions.K8sVersions, state.Filters)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid IKS versions filter",
+			err.Error(),
+		)
+		return
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return compareK8sVersions(versions[i].K8sVersion, versions[j].K8sVersion) > 0
+	})
+
+	state.Versions = []models.IKSVersion{}
+	state.Runtimes = []types.String{}
+	state.Latest = types.StringNull()
+	state.Default = types.StringNull()
+
+	seenRuntimes := map[string]bool{}
+	for _, v := range versions {
+		state.Versions = append(state.Versions, models.IKSVersion{
+			Version: types.StringValue(v.K8sVersion),
+			Runtime: types.StringValue(v.RuntimeName),
+		})
+		if !seenRuntimes[v.RuntimeName] {
+			seenRuntimes[v.RuntimeName] = true
+			state.Runtimes = append(state.Runtimes, types.StringValue(v.RuntimeName))
+		}
+		if state.Latest.IsNull() {
+			state.Latest = types.StringValue(v.K8sVersion)
+		}
+		if state.Default.IsNull() && strings.EqualFold(v.RuntimeName, itacservices.DefaultIKSRuntime) {
+			state.Default = types.StringValue(v.K8sVersion)
+		}
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func filterIKSVersions(all []itacservices.IKSK8sVersion, filters []KVFilter) ([]itacservices.IKSK8sVersion, error) {
+	filtered := all
+	for _, filter := range filters {
+		matched := []itacservices.IKSK8sVersion{}
+		for _, v := range filtered {
+			for _, value := range filter.Values {
+				var ok bool
+				switch filter.Key {
+				case "runtime":
+					ok = strings.EqualFold(v.RuntimeName, value)
+				case "version":
+					ok = v.K8sVersion == value || strings.HasPrefix(v.K8sVersion, strings.TrimSuffix(value, ".")+".")
+				default:
+					return nil, fmt.Errorf("unsupported filter %q, expected one of: runtime, version", filter.Key)
+				}
+				if ok {
+					matched = append(matched, v)
+					break
+				}
+			}
+		}
+		filtered = matched
+	}
+	return filtered, nil
+}
+
+// compareK8sVersions compares dotted version strings such as "1.30.2" or "v1.29"
+// numerically, returning a positive value when a is newer than b.
+func compareK8sVersions(a, b string) int {
+	pa := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	pb := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var na, nb int
+		if i < len(pa) {
+			na, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(pb[i])
+		}
+		if na != nb {
+			return na - nb
+		}
+	}
+	return 0
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// importScope is the client an import resolves through, for the region and cloud account an
+// import ID may start with as region/cloudaccount/<id>, and the rest of the ID.
+type importScope struct {
+	client *itacservices.IDCServicesClient
+	id     string
+	// scoped is set when the import ID named the region and cloud account
+	scoped bool
+}
+
+// resolveImportScope splits the region and cloud account off an import ID. Without them,
+// or with empty ones, the provider region and cloud account are used.
+func resolveImportScope(ctx context.Context, client *itacservices.IDCServicesClient, importID string) (importScope, diag.Diagnostics) {
+	parts := strings.SplitN(importID, "/", 3)
+	if len(parts) != 3 {
+		return importScope{client: client, id: importID}, nil
+	}
+
+	regional, diags := regionalClient(ctx, client, types.StringValue(parts[0]), types.StringValue(parts[1]))
+	if diags.HasError() {
+		return importScope{}, diags
+	}
+	return importScope{client: regional, id: parts[2], scoped: true}, diags
+}
+
+// region returns the region to import into, null to default to the provider region.
+func (s importScope) region() types.String {
+	if !s.scoped {
+		return types.StringNull()
+	}
+	return types.StringValue(*s.client.Region)
+}
+
+// cloudaccount returns the cloud account to import into, null to default to the provider one.
+func (s importScope) cloudaccount() types.String {
+	if !s.scoped {
+		return types.StringNull()
+	}
+	return types.StringValue(*s.client.Cloudaccount)
+}
+
+// setState records the region and cloud account of a scoped import in state, so Read uses
+// them. cloudaccount is the path of the resource's cloud account attribute.
+func (s importScope) setState(ctx context.Context, state *tfsdk.State, cloudaccount path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !s.scoped {
+		return diags
+	}
+	diags.Append(state.SetAttribute(ctx, path.Root("region"), s.region())...)
+	diags.Append(state.SetAttribute(ctx, cloudaccount, s.cloudaccount())...)
+	return diags
+}
+
+// importCandidate is an existing resource an import identifier may refer to.
+type importCandidate struct {
+	ID   string
+	Name string
+}
+
+// resolveImportID returns the ID of the candidate matching importID. An exact ID match
+// wins, otherwise the name must match exactly one candidate.
+func resolveImportID(kind, importID string, candidates []importCandidate) (string, error) {
+	matches := []string{}
+	for _, c := range candidates {
+		if c.ID == importID {
+			return c.ID, nil
+		}
+		if c.Name == importID {
+			matches = append(matches, c.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s found with ID or name %q", kind, importID)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%d %s resources are named %q, import by ID instead", len(matches), kind, importID)
+	}
+}
+
+// resolveImportIDOrName returns importID when byID finds a resource with that ID, and
+// when there is none the ID of the resource byName finds with that name. Other errors of
+// byID are returned as they are.
+func resolveImportIDOrName(ctx context.Context, kind, importID string,
+	byID func(context.Context, string) error,
+	byName func(context.Context, string) (string, error)) (string, error) {
+	err := byID(ctx, importID)
+	if err == nil {
+		return importID, nil
+	}
+	if !common.IsNotFound(err) {
+		return "", fmt.Errorf("error reading %s %s: %w", kind, importID, err)
+	}
+
+	id, err := byName(ctx, importID)
+	if err != nil {
+		return "", fmt.Errorf("no %s found with ID or name %q: %w", kind, importID, err)
+	}
+	return id, nil
+}
+
+// resolveIKSClusterImportID resolves a cluster UUID or cluster name to the cluster UUID.
+func resolveIKSClusterImportID(ctx context.Context, client *itacservices.IDCServicesClient, importID string) (string, error) {
+	clusters, _, err := client.GetKubernetesClusters(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error listing iks clusters: %w", err)
+	}
+
+	candidates := []importCandidate{}
+	for _, c := range clusters.Clusters {
+		candidates = append(candidates, importCandidate{ID: c.ResourceId, Name: c.Name})
+	}
+	return resolveImportID("iks cluster", importID, candidates)
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+)
+
+func TestResolveImportIDOrName(t *testing.T) {
+	byName := func(_ context.Context, name string) (string, error) {
+		return "id-of-" + name, nil
+	}
+
+	tests := []struct {
+		name    string
+		byIDErr error
+		want    string
+		wantErr string
+	}{
+		{name: "id", want: "web"},
+		{name: "name", byIDErr: &common.APIError{StatusCode: http.StatusNotFound}, want: "id-of-web"},
+		{name: "unauthorized", byIDErr: &common.APIError{StatusCode: http.StatusUnauthorized}, wantErr: "error reading filesystem web: Unauthorized"},
+		{name: "unavailable", byIDErr: errors.New("connection refused"), wantErr: "connection refused"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			byID := func(context.Context, string) error { return tt.byIDErr }
+			id, err := resolveImportIDOrName(context.Background(), "filesystem", "web", byID, byName)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != tt.want {
+				t.Errorf("expected id %q, got %q", tt.want, id)
+			}
+		})
+	}
+}
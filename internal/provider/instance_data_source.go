@@ -29,6 +29,7 @@ var (
 
 // storagesDataSourceModel maps the data source schema data.
 type instanceDataSourceModel struct {
+	Name      types.String           `tfsdk:"name"`
 	Instances []models.InstanceModel `tfsdk:"instances"`
 }
 
@@ -58,6 +59,10 @@ func (d *instanceDataSource) Metadata(_ context.Context, req datasource.Metadata
 func (d *instanceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the instance with this name.",
+			},
 			"instances": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -153,17 +158,36 @@ func (d *instanceDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 func (d *instanceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
 	var state instanceDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state.Instances = []models.InstanceModel{}
 
-	instanceList, err := d.client.GetInstances(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read IDC Instances",
-			err.Error(),
-		)
-		return
+	instances := []itacservices.Instance{}
+	if !state.Name.IsNull() {
+		inst, err := d.client.GetInstanceByName(ctx, state.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read IDC Instance "+state.Name.ValueString(),
+				err.Error(),
+			)
+			return
+		}
+		instances = append(instances, *inst)
+	} else {
+		instanceList, err := d.client.GetInstances(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read IDC Instances",
+				err.Error(),
+			)
+			return
+		}
+		instances = instanceList.Instances
 	}
-	for _, inst := range instanceList.Instances {
+	for _, inst := range instances {
 		instModel := models.InstanceModel{
 			Cloudaccount: types.StringValue(inst.Metadata.Cloudaccount),
 			Name:         types.StringValue(inst.Metadata.Name),
@@ -233,7 +257,7 @@ func (d *instanceDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
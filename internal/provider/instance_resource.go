@@ -3,10 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"terraform-provider-intelcloud/internal/models"
 	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -23,14 +25,16 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &computeInstanceResource{}
-	_ resource.ResourceWithConfigure = &computeInstanceResource{}
+	_ resource.Resource                = &computeInstanceResource{}
+	_ resource.ResourceWithConfigure   = &computeInstanceResource{}
+	_ resource.ResourceWithImportState = &computeInstanceResource{}
 )
 
 // orderFilesystemModel maps the resource schema data.
 type computeInstanceResourceModel struct {
 	ID               types.String         `tfsdk:"id"`
 	Cloudaccount     types.String         `tfsdk:"cloudaccount"`
+	Region           types.String         `tfsdk:"region"`
 	Name             types.String         `tfsdk:"name"`
 	AvailabilityZone types.String         `tfsdk:"availability_zone"`
 	Spec             *models.InstanceSpec `tfsdk:"spec"`
@@ -38,6 +42,7 @@ type computeInstanceResourceModel struct {
 	Interfaces       types.List           `tfsdk:"interfaces"`
 	SSHProxy         types.Object         `tfsdk:"ssh_proxy"`
 	AccessInfo       types.Object         `tfsdk:"access_info"`
+	AdoptExisting    types.Bool           `tfsdk:"adopt_existing"`
 	Timeouts         *timeoutsModel       `tfsdk:"timeouts"`
 }
 
@@ -86,9 +91,12 @@ func (r *computeInstanceResource) Schema(_ context.Context, _ resource.SchemaReq
 			"name": schema.StringAttribute{
 				Required: true,
 			},
-			"cloudaccount": schema.StringAttribute{
-				Computed: true,
+			"adopt_existing": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Adopt an existing instance with the same name instead of creating a new one.",
 			},
+			"cloudaccount": cloudaccountAttribute(),
+			"region":       regionAttribute(),
 			"availability_zone": schema.StringAttribute{
 				Computed: true,
 			},
@@ -227,67 +235,95 @@ func (r *computeInstanceResource) Create(ctx context.Context, req resource.Creat
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
-	tflog.Info(ctx, "making a call to IDC Service to createVnetIfNotExist")
-	vnetResp, err := r.client.CreateVNetIfNotFound(ctx, *r.client.Region)
-	if err != nil || vnetResp == nil {
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Region = types.StringValue(*client.Region)
+
+	instResp, err := adoptExisting(ctx, plan.AdoptExisting, plan.Name.ValueString(), client.GetInstanceByName)
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating order",
-			"Could not create order, unexpected error: "+err.Error(),
+			"Could not look up existing instance "+plan.Name.ValueString()+": "+err.Error(),
 		)
 		return
 	}
+	if instResp != nil {
+		tflog.Info(ctx, "adopting existing instance", map[string]any{"ID": instResp.Metadata.ResourceId})
+	} else {
+		tflog.Info(ctx, "making a call to IDC Service to createVnetIfNotExist")
+		vnetResp, err := client.CreateVNetIfNotFound(ctx, *client.Region)
+		if err != nil || vnetResp == nil {
+			resp.Diagnostics.AddError(
+				"Error creating order",
+				"Could not create order, unexpected error: "+err.Error(),
+			)
+			return
+		}
 
-	sshKeys := []string{}
-	for _, k := range plan.Spec.SSHPublicKeyNames {
-		sshKeys = append(sshKeys, k.ValueString())
-	}
+		availabilityZone, err := client.DefaultAvailabilityZone()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating order",
+				"Could not create order, unexpected error: "+err.Error(),
+			)
+			return
+		}
 
-	inArg := itacservices.InstanceCreateRequest{
-		Metadata: struct {
-			Name string "json:\"name\""
-		}{
-			Name: plan.Name.ValueString(),
-		},
-		Spec: struct {
-			AvailabilityZone string "json:\"availabilityZone\""
-			InstanceGroup    string "json:\"instanceGroup,omitempty\""
-			InstanceType     string "json:\"instanceType\""
-			Interfaces       []struct {
-				Name string "json:\"name\""
-				VNet string "json:\"vNet\""
-			} "json:\"interfaces\""
-			MachineImage        string   "json:\"machineImage\""
-			SshPublicKeyNames   []string "json:\"sshPublicKeyNames\""
-			UserData            string   "json:\"userData,omitempty\""
-			QuickConnectEnabled string   "json:\"quickConnectEnabled,omitempty\""
-		}{
-			AvailabilityZone: fmt.Sprintf("%sa", *r.client.Region),
-			InstanceGroup:    plan.Spec.InstanceGroup.ValueString(),
-			Interfaces: []struct {
+		sshKeys := []string{}
+		for _, k := range plan.Spec.SSHPublicKeyNames {
+			sshKeys = append(sshKeys, k.ValueString())
+		}
+
+		inArg := itacservices.InstanceCreateRequest{
+			Metadata: struct {
 				Name string "json:\"name\""
-				VNet string "json:\"vNet\""
 			}{
-				{
-					Name: "eth0",
-					VNet: vnetResp.Metadata.Name,
+				Name: plan.Name.ValueString(),
+			},
+			Spec: struct {
+				AvailabilityZone string "json:\"availabilityZone\""
+				InstanceGroup    string "json:\"instanceGroup,omitempty\""
+				InstanceType     string "json:\"instanceType\""
+				Interfaces       []struct {
+					Name string "json:\"name\""
+					VNet string "json:\"vNet\""
+				} "json:\"interfaces\""
+				MachineImage        string   "json:\"machineImage\""
+				SshPublicKeyNames   []string "json:\"sshPublicKeyNames\""
+				UserData            string   "json:\"userData,omitempty\""
+				QuickConnectEnabled string   "json:\"quickConnectEnabled,omitempty\""
+			}{
+				AvailabilityZone: availabilityZone,
+				InstanceGroup:    plan.Spec.InstanceGroup.ValueString(),
+				Interfaces: []struct {
+					Name string "json:\"name\""
+					VNet string "json:\"vNet\""
+				}{
+					{
+						Name: "eth0",
+						VNet: vnetResp.Metadata.Name,
+					},
 				},
+				InstanceType:        plan.Spec.InstanceType.ValueString(),
+				MachineImage:        plan.Spec.MachineImage.ValueString(),
+				UserData:            plan.Spec.UserData.ValueString(),
+				SshPublicKeyNames:   sshKeys,
+				QuickConnectEnabled: capitalize(plan.Spec.QuickConnectEnabled.ValueString()),
 			},
-			InstanceType:        plan.Spec.InstanceType.ValueString(),
-			MachineImage:        plan.Spec.MachineImage.ValueString(),
-			UserData:            plan.Spec.UserData.ValueString(),
-			SshPublicKeyNames:   sshKeys,
-			QuickConnectEnabled: capitalize(plan.Spec.QuickConnectEnabled.ValueString()),
-		},
-	}
+		}
 
-	tflog.Info(ctx, "making a call to IDC Service for create instance")
-	instResp, err := r.client.CreateInstance(ctx, &inArg, false)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating order",
-			"Could not create order, unexpected error: "+err.Error(),
-		)
-		return
+		tflog.Info(ctx, "making a call to IDC Service for create instance")
+		instResp, err = client.CreateInstance(ctx, &inArg, false)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating order",
+				"Could not create order, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
 
 	// Map response body to schema and populate Computed attribute values
@@ -342,7 +378,7 @@ func (r *computeInstanceResource) Create(ctx context.Context, req resource.Creat
 	}
 
 	// Set quick connect URL if required
-	plan.Spec.QuickConnectUrl = types.StringValue(r.getQuickConnectUrl(plan.Spec.QuickConnectEnabled, instResp))
+	plan.Spec.QuickConnectUrl = types.StringValue(getQuickConnectUrl(client, plan.Spec.QuickConnectEnabled, instResp))
 
 	// Ensure timeout block is preserved
 	//plan.SetTimeout()
@@ -379,8 +415,20 @@ func (r *computeInstanceResource) Read(ctx context.Context, req resource.ReadReq
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
 
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Region = types.StringValue(*client.Region)
+
 	// Get refreshed order value from IDC Service
-	instance, err := r.client.GetInstanceByResourceId(ctx, state.ID.ValueString())
+	instance, err := client.GetInstanceByResourceId(ctx, state.ID.ValueString())
+	if common.IsNotFound(err) {
+		tflog.Warn(ctx, "instance not found, removing from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading IDC Compute Instance resource",
@@ -398,14 +446,22 @@ func (r *computeInstanceResource) Read(ctx context.Context, req resource.ReadReq
 	state.ID = types.StringValue(instance.Metadata.ResourceId)
 	state.Name = types.StringValue(instance.Metadata.Name)
 	state.AvailabilityZone = types.StringValue(instance.Spec.AvailabilityZone)
+	origSpec := state.Spec
 	state.Spec = &models.InstanceSpec{
-		InstanceGroup:       types.StringValue(instance.Spec.InstanceGroup),
+		InstanceGroup:       stringValueOrNull(instance.Spec.InstanceGroup),
 		InstanceType:        types.StringValue(instance.Spec.InstanceType),
 		MachineImage:        types.StringValue(instance.Spec.MachineImage),
-		UserData:            types.StringValue(instance.Spec.UserData),
-		QuickConnectEnabled: types.StringValue(instance.Spec.QuickConnectEnabled),
+		UserData:            stringValueOrNull(instance.Spec.UserData),
+		QuickConnectEnabled: stringValueOrNull(strings.ToLower(instance.Spec.QuickConnectEnabled)),
 		QuickConnectUrl:     types.StringValue(instance.Spec.QuickConnectUrl),
 	}
+	// the service capitalizes the quick connect flag, keep the configured spelling
+	if origSpec != nil && strings.EqualFold(origSpec.QuickConnectEnabled.ValueString(), instance.Spec.QuickConnectEnabled) {
+		state.Spec.QuickConnectEnabled = origSpec.QuickConnectEnabled
+	}
+	if instance.Spec.QuickConnectUrl == "" {
+		state.Spec.QuickConnectUrl = types.StringValue(getQuickConnectUrl(client, state.Spec.QuickConnectEnabled, instance))
+	}
 
 	for _, k := range instance.Spec.SshPublicKeyNames {
 		state.Spec.SSHPublicKeyNames = append(state.Spec.SSHPublicKeyNames, types.StringValue(k))
@@ -436,8 +492,12 @@ func (r *computeInstanceResource) Read(ctx context.Context, req resource.ReadReq
 
 	infs := []models.NetworkInterface{}
 	for _, nic := range instance.Status.Interfaces {
+		addr := ""
+		if len(nic.Addresses) > 0 {
+			addr = nic.Addresses[0]
+		}
 		inf := models.NetworkInterface{
-			Addresses:    types.StringValue(nic.Addresses[0]),
+			Addresses:    types.StringValue(addr),
 			DNSName:      types.StringValue(nic.DNSName),
 			Gateway:      types.StringValue(nic.Gateway),
 			Name:         types.StringValue(nic.Name),
@@ -471,8 +531,32 @@ func (r *computeInstanceResource) Update(ctx context.Context, req resource.Updat
 }
 
 func (r *computeInstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	scope, diags := resolveImportScope(ctx, r.client, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Accept either the resource ID or the name of the instance
+	id, err := resolveImportIDOrName(ctx, "instance", scope.id,
+		func(ctx context.Context, id string) error {
+			_, err := scope.client.GetInstanceByResourceId(ctx, id)
+			return err
+		},
+		func(ctx context.Context, name string) (string, error) {
+			inst, err := scope.client.GetInstanceByName(ctx, name)
+			if err != nil {
+				return "", err
+			}
+			return inst.Metadata.ResourceId, nil
+		})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import IDC Compute Instance resource", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(scope.setState(ctx, &resp.State, path.Root("cloudaccount"))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -497,8 +581,14 @@ func (r *computeInstanceResource) Delete(ctx context.Context, req resource.Delet
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
 
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the order from IDC Services
-	err = r.client.DeleteInstanceByResourceId(ctx, state.ID.ValueString())
+	err = client.DeleteInstanceByResourceId(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting IDC Filesystem resource",
@@ -506,14 +596,20 @@ func (r *computeInstanceResource) Delete(ctx context.Context, req resource.Delet
 		)
 		return
 	}
+	if err := client.WaitForInstanceDeleted(ctx, state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting IDC Instance resource",
+			"Could not delete IDC Instance resource ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+	}
 }
 
-func (r *computeInstanceResource) getQuickConnectUrl(quickConnectEnabled types.String, inst *itacservices.Instance) string {
+func getQuickConnectUrl(client *itacservices.IDCServicesClient, quickConnectEnabled types.String, inst *itacservices.Instance) string {
 	if capitalize(quickConnectEnabled.ValueString()) == "True" {
 		return fmt.Sprintf("https://%s.connect.%s.devcloudtenant.io/v1/connect/%s/%s",
 			inst.Metadata.ResourceId,
-			*r.client.Region,
-			*r.client.Cloudaccount,
+			*client.Region,
+			*client.Cloudaccount,
 			inst.Metadata.ResourceId)
 	}
 	return ""
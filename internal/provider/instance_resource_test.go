@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccInstanceResource(t *testing.T) {
+	server, providerConfig := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(server, "instances"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "intelcloud_sshkey" "test" {
+  metadata = {
+    name = "tf-acc-instance-key"
+  }
+  spec = {
+    ssh_public_key = "` + testAccPublicKey + `"
+  }
+}
+
+resource "intelcloud_instance" "test" {
+  name = "tf-acc-instance"
+  spec = {
+    instance_type        = "vm-spr-sml"
+    machine_image        = "ubuntu-2204-jammy-v20240308"
+    ssh_public_key_names = [intelcloud_sshkey.test.metadata.name]
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("intelcloud_instance.test", "id"),
+					resource.TestCheckResourceAttr("intelcloud_instance.test", "status", "Ready"),
+					resource.TestCheckResourceAttr("intelcloud_instance.test", "availability_zone", "us-region-1a"),
+					resource.TestCheckResourceAttr("intelcloud_instance.test", "interfaces.0.vnet", "us-region-1a-default"),
+					func(*terraform.State) error {
+						if n := server.Count("vnets"); n != 1 {
+							return fmt.Errorf("expected the default vnet to be created, found %d vnets", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
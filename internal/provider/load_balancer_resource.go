@@ -0,0 +1,609 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"terraform-provider-intelcloud/internal/models"
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &loadBalancerResource{}
+	_ resource.ResourceWithConfigure      = &loadBalancerResource{}
+	_ resource.ResourceWithImportState    = &loadBalancerResource{}
+	_ resource.ResourceWithValidateConfig = &loadBalancerResource{}
+)
+
+// loadBalancerResourceModel maps the resource schema data.
+type loadBalancerResourceModel struct {
+	ID             types.String                       `tfsdk:"id"`
+	Cloudaccount   types.String                       `tfsdk:"cloudaccount"`
+	Region         types.String                       `tfsdk:"region"`
+	Name           types.String                       `tfsdk:"name"`
+	Listeners      []models.LoadBalancerListenerModel `tfsdk:"listeners"`
+	SourceIps      types.List                         `tfsdk:"source_ips"`
+	Vip            types.String                       `tfsdk:"vip"`
+	State          types.String                       `tfsdk:"state"`
+	ListenerStatus types.List                         `tfsdk:"listener_status"`
+	Timeouts       *timeoutsModel                     `tfsdk:"timeouts"`
+}
+
+// NewLoadBalancerResource is a helper function to simplify the provider implementation.
+func NewLoadBalancerResource() resource.Resource {
+	return &loadBalancerResource{}
+}
+
+// loadBalancerResource is the resource implementation.
+type loadBalancerResource struct {
+	client *itacservices.IDCServicesClient
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *loadBalancerResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*itacservices.IDCServicesClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *itacservices.IDCServicesClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *loadBalancerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_load_balancer"
+}
+
+// Schema defines the schema for the resource.
+func (r *loadBalancerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Load balancer for compute instances.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cloudaccount": cloudaccountAttribute(),
+			"region":       regionAttribute(),
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the load balancer. Changing this forces a new load balancer.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_ips": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Source IPs or CIDRs allowed to reach the load balancer, `any` allows all.",
+			},
+			"listeners": schema.ListNestedAttribute{
+				Required:    true,
+				Description: "List of listener configurations.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"port": schema.Int64Attribute{
+							Required:    true,
+							Description: "Listener port.",
+						},
+						"protocol": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("TCP"),
+							Description: "Listener protocol.",
+						},
+						"source_ips": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Source IPs or CIDRs allowed to reach this listener.",
+						},
+						"pool": schema.SingleNestedAttribute{
+							Required:    true,
+							Description: "Pool configuration for the listener. Exactly one of instance_resource_ids or instance_selectors selects the pool members.",
+							Attributes: map[string]schema.Attribute{
+								"port": schema.Int64Attribute{
+									Required:    true,
+									Description: "Pool port.",
+								},
+								"monitor": schema.StringAttribute{
+									Required:    true,
+									Description: "Health monitor type (e.g., tcp, http, https).",
+								},
+								"load_balancing_mode": schema.StringAttribute{
+									Optional:    true,
+									Computed:    true,
+									Default:     stringdefault.StaticString("roundRobin"),
+									Description: "Load balancing mode (e.g., roundRobin, leastConnections).",
+								},
+								"instance_resource_ids": schema.ListAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "IDs of the instances that are the pool members.",
+								},
+								"instance_selectors": schema.MapAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Labels selecting the instances that are the pool members.",
+								},
+							},
+						},
+					},
+				},
+			},
+			"vip": schema.StringAttribute{
+				Computed:    true,
+				Description: "Virtual IP of the load balancer.",
+			},
+			"state": schema.StringAttribute{
+				Computed: true,
+			},
+			"listener_status": schema.ListAttribute{
+				ElementType: types.ObjectType{AttrTypes: models.LoadBalancerListenerStatusAttributes},
+				Computed:    true,
+				Description: "Status of each listener as reported by the load balancer service.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"resource_timeout": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Timeout for resource operation, supports 1s, 2m, 3h etc.",
+						Default:     stringdefault.StaticString(LoadBalancerResourceTimeout),
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the source IPs and that every pool has a single member selection.
+func (r *loadBalancerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config loadBalancerResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateLoadBalancerSourceIps(path.Root("source_ips"), config.SourceIps, &resp.Diagnostics)
+	for i, listener := range config.Listeners {
+		listenerPath := path.Root("listeners").AtListIndex(i)
+		validateLoadBalancerSourceIps(listenerPath.AtName("source_ips"), listener.SourceIps, &resp.Diagnostics)
+
+		if listener.Pool.InstanceResourceIds.IsNull() == listener.Pool.InstanceSelectors.IsNull() {
+			resp.Diagnostics.AddAttributeError(listenerPath.AtName("pool"),
+				"Invalid load balancer pool",
+				"Exactly one of instance_resource_ids or instance_selectors must be set")
+		}
+	}
+}
+
+func validateLoadBalancerSourceIps(p path.Path, sourceIps types.List, diags *diag.Diagnostics) {
+	if sourceIps.IsNull() || sourceIps.IsUnknown() {
+		return
+	}
+	for i, v := range sourceIps.Elements() {
+		ip, ok := v.(types.String)
+		if !ok || ip.IsUnknown() || ip.IsNull() || ip.ValueString() == "any" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(ip.ValueString()); err == nil {
+			continue
+		}
+		if net.ParseIP(ip.ValueString()) == nil {
+			diags.AddAttributeError(p.AtListIndex(i), "Invalid source IP",
+				fmt.Sprintf("%q is not an IP address, a CIDR or any", ip.ValueString()))
+		}
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *loadBalancerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan loadBalancerResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// use timeouts if requested by the user
+	createTimeout, err := plan.Timeouts.GetTimeouts(LoadBalancerResourceName)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", "Could not parse create timeout: "+err.Error())
+	}
+	// Use the timeout context
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Region = types.StringValue(*client.Region)
+
+	spec, diags := loadBalancerSpecFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inArg := itacservices.LoadBalancerCreateRequest{
+		Spec: *spec,
+	}
+	inArg.Metadata.Name = plan.Name.ValueString()
+
+	tflog.Info(ctx, "making a call to IDC Service for create load balancer")
+	lb, err := client.CreateLoadBalancer(ctx, &inArg)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating load balancer",
+			"Could not create load balancer, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	currState, err := refreshLoadBalancerResourceModel(ctx, lb)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading load balancer resource",
+			"Could not read load balancer resource ID "+lb.Metadata.ResourceID+": "+err.Error(),
+		)
+		return
+	}
+	currState.Timeouts = plan.Timeouts
+	currState.Region = plan.Region
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, currState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *loadBalancerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var orig loadBalancerResourceModel
+
+	diags := req.State.Get(ctx, &orig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// use timeouts if requested by the user
+	readTimeout, err := orig.Timeouts.GetTimeouts(LoadBalancerResourceName)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", "Could not parse read timeout: "+err.Error())
+	}
+	// Use the timeout context
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	client, diags := regionalClient(ctx, r.client, orig.Region, orig.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed value from IDC Service
+	lb, err := client.GetLoadBalancerByID(ctx, orig.ID.ValueString())
+	if common.IsNotFound(err) {
+		tflog.Warn(ctx, "load balancer not found, removing from state", map[string]any{"id": orig.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading load balancer resource",
+			"Could not read load balancer resource ID "+orig.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state, err := refreshLoadBalancerResourceModel(ctx, lb)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading load balancer resource",
+			"Could not read load balancer resource ID "+orig.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	state.Timeouts = orig.Timeouts
+	state.Region = types.StringValue(*client.Region)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *loadBalancerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state loadBalancerResourceModel
+
+	// Retrieve the desired configuration from the plan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// use timeouts if requested by the user
+	updateTimeout, err := plan.Timeouts.GetTimeouts(LoadBalancerResourceName)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", "Could not parse update timeout: "+err.Error())
+	}
+	// Use the timeout context
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Region = types.StringValue(*client.Region)
+
+	spec, diags := loadBalancerSpecFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inArg := itacservices.LoadBalancerUpdateRequest{
+		Spec: itacservices.LoadBalancerUpdateSpec{
+			Listeners: spec.Listeners,
+			Security:  spec.Security,
+		},
+	}
+
+	tflog.Info(ctx, "making a call to IDC Service for update load balancer", map[string]any{"ID": state.ID.ValueString()})
+	lb, err := client.UpdateLoadBalancer(ctx, state.ID.ValueString(), &inArg)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating load balancer",
+			"Could not update load balancer resource ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	currState, err := refreshLoadBalancerResourceModel(ctx, lb)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading load balancer resource",
+			"Could not read load balancer resource ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	currState.Timeouts = plan.Timeouts
+	currState.Region = plan.Region
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, currState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *loadBalancerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Get current state
+	var state loadBalancerResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, err := state.Timeouts.GetTimeouts(LoadBalancerResourceName)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", "Could not parse delete timeout: "+err.Error())
+	}
+	// Use the timeout context
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = client.DeleteLoadBalancer(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting load balancer resource",
+			"Could not delete load balancer resource ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if err := client.WaitForLoadBalancerDeleted(ctx, state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting load balancer resource",
+			"Could not delete load balancer resource ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+func (r *loadBalancerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scope, diags := resolveImportScope(ctx, r.client, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Accept either the resource ID or the name of the load balancer
+	items, err := scope.client.GetLoadBalancers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to import load balancer resource",
+			"Could not list load balancers: "+err.Error(),
+		)
+		return
+	}
+
+	candidates := []importCandidate{}
+	for _, item := range items.LoadBalancers {
+		candidates = append(candidates, importCandidate{ID: item.Metadata.ResourceID, Name: item.Metadata.Name})
+	}
+	id, err := resolveImportID("load balancer", scope.id, candidates)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import load balancer resource", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(scope.setState(ctx, &resp.State, path.Root("cloudaccount"))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func loadBalancerSpecFromModel(ctx context.Context, m *loadBalancerResourceModel) (*itacservices.LoadBalancerSpec, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	spec := &itacservices.LoadBalancerSpec{
+		Listeners: []itacservices.LoadBalancerListener{},
+	}
+
+	diags.Append(m.SourceIps.ElementsAs(ctx, &spec.Security.SourceIps, false)...)
+	for _, l := range m.Listeners {
+		listener := itacservices.LoadBalancerListener{
+			Port:     int32(l.Port.ValueInt64()),
+			Protocol: l.Protocol.ValueString(),
+			Pool: itacservices.LoadBalancerPool{
+				Port:              int32(l.Pool.Port.ValueInt64()),
+				Monitor:           l.Pool.Monitor.ValueString(),
+				LoadBalancingMode: l.Pool.LoadBalancingMode.ValueString(),
+			},
+		}
+		if !l.SourceIps.IsNull() {
+			diags.Append(l.SourceIps.ElementsAs(ctx, &listener.Security.SourceIps, false)...)
+		}
+		if !l.Pool.InstanceResourceIds.IsNull() {
+			diags.Append(l.Pool.InstanceResourceIds.ElementsAs(ctx, &listener.Pool.InstanceResourceIds, false)...)
+		}
+		if !l.Pool.InstanceSelectors.IsNull() {
+			diags.Append(l.Pool.InstanceSelectors.ElementsAs(ctx, &listener.Pool.InstanceSelectors, false)...)
+		}
+		spec.Listeners = append(spec.Listeners, listener)
+	}
+	return spec, diags
+}
+
+// loadBalancerListenerStatusFromAPI maps the per-listener status reported by the load balancer service.
+func loadBalancerListenerStatusFromAPI(ctx context.Context, status []itacservices.IKSLoadBalancerListenerStatus) (types.List, error) {
+	listenerStatus := []models.LoadBalancerListenerStatus{}
+	for _, l := range status {
+		members := []models.LoadBalancerPoolMember{}
+		for _, m := range l.PoolMembers {
+			members = append(members, models.LoadBalancerPoolMember{
+				InstanceRef: types.StringValue(m.InstanceRef),
+				IP:          types.StringValue(m.IP),
+			})
+		}
+		listenerStatus = append(listenerStatus, models.LoadBalancerListenerStatus{
+			Port:        types.Int64Value(int64(l.Port)),
+			State:       types.StringValue(l.State),
+			Message:     types.StringValue(l.Message),
+			VipID:       types.Int64Value(int64(l.VipID)),
+			PoolID:      types.Int64Value(int64(l.PoolID)),
+			PoolMembers: members,
+		})
+	}
+	list, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: models.LoadBalancerListenerStatusAttributes}, listenerStatus)
+	if diags.HasError() {
+		return list, fmt.Errorf("error parsing listener status")
+	}
+	return list, nil
+}
+
+func refreshLoadBalancerResourceModel(ctx context.Context, lb *itacservices.LoadBalancer) (*loadBalancerResourceModel, error) {
+	state := &loadBalancerResourceModel{}
+
+	state.ID = types.StringValue(lb.Metadata.ResourceID)
+	state.Cloudaccount = types.StringValue(lb.Metadata.CloudAccountID)
+	state.Name = types.StringValue(lb.Metadata.Name)
+	state.Vip = types.StringValue(lb.Status.VIP)
+	state.State = types.StringValue(lb.Status.State)
+
+	state.SourceIps = stringListValue(lb.Spec.Security.SourceIps)
+
+	for _, l := range lb.Spec.Listeners {
+		listener := models.LoadBalancerListenerModel{
+			Port:      types.Int64Value(int64(l.Port)),
+			Protocol:  types.StringValue(l.Protocol),
+			SourceIps: types.ListNull(types.StringType),
+			Pool: models.LoadBalancerPoolModel{
+				Port:                types.Int64Value(int64(l.Pool.Port)),
+				Monitor:             types.StringValue(l.Pool.Monitor),
+				LoadBalancingMode:   types.StringValue(l.Pool.LoadBalancingMode),
+				InstanceResourceIds: types.ListNull(types.StringType),
+				InstanceSelectors:   types.MapNull(types.StringType),
+			},
+		}
+		if len(l.Security.SourceIps) > 0 {
+			listener.SourceIps = stringListValue(l.Security.SourceIps)
+		}
+		if len(l.Pool.InstanceResourceIds) > 0 {
+			listener.Pool.InstanceResourceIds = stringListValue(l.Pool.InstanceResourceIds)
+		}
+		if len(l.Pool.InstanceSelectors) > 0 {
+			selectors := map[string]attr.Value{}
+			for k, v := range l.Pool.InstanceSelectors {
+				selectors[k] = types.StringValue(v)
+			}
+			listener.Pool.InstanceSelectors = types.MapValueMust(types.StringType, selectors)
+		}
+		state.Listeners = append(state.Listeners, listener)
+	}
+
+	listenerStatus, err := loadBalancerListenerStatusFromAPI(ctx, lb.Status.Listeners)
+	if err != nil {
+		return state, err
+	}
+	state.ListenerStatus = listenerStatus
+
+	return state, nil
+}
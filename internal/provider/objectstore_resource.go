@@ -3,10 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net"
 
 	"terraform-provider-intelcloud/internal/models"
 	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -17,21 +20,24 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &objectStorageResource{}
-	_ resource.ResourceWithConfigure   = &objectStorageResource{}
-	_ resource.ResourceWithImportState = &objectStorageResource{}
+	_ resource.Resource                   = &objectStorageResource{}
+	_ resource.ResourceWithConfigure      = &objectStorageResource{}
+	_ resource.ResourceWithImportState    = &objectStorageResource{}
+	_ resource.ResourceWithValidateConfig = &objectStorageResource{}
 )
 
 // objectstorageResourceModel maps the resource schema data.
 type objectStorageResourceModel struct {
 	ID              types.String   `tfsdk:"id"`
 	Cloudaccount    types.String   `tfsdk:"cloudaccount"`
+	Region          types.String   `tfsdk:"region"`
 	Name            types.String   `tfsdk:"name"`
 	Versioned       types.Bool     `tfsdk:"versioned"`
 	Size            types.String   `tfsdk:"size"`
 	Status          types.String   `tfsdk:"status"`
 	PrivateEndpoint types.String   `tfsdk:"private_endpoint"`
 	SecurityGroups  types.List     `tfsdk:"security_groups"`
+	AdoptExisting   types.Bool     `tfsdk:"adopt_existing"`
 	Timeouts        *timeoutsModel `tfsdk:"timeouts"`
 }
 
@@ -80,9 +86,12 @@ func (r *objectStorageResource) Schema(_ context.Context, _ resource.SchemaReque
 			"name": schema.StringAttribute{
 				Required: true,
 			},
-			"cloudaccount": schema.StringAttribute{
-				Computed: true,
+			"adopt_existing": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Adopt an existing bucket with the same name instead of creating a new one.",
 			},
+			"cloudaccount": cloudaccountAttribute(),
+			"region":       regionAttribute(),
 			"versioned": schema.BoolAttribute{
 				Required: true,
 			},
@@ -96,18 +105,22 @@ func (r *objectStorageResource) Schema(_ context.Context, _ resource.SchemaReque
 				Computed: true,
 			},
 			"security_groups": schema.ListNestedAttribute{
-				Optional: true,
-				Computed: true,
+				Optional:    true,
+				Computed:    true,
+				Description: "Subnets allowed to reach the private endpoint of the bucket. When not set, the allowlist is managed outside of Terraform.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"gateway": schema.StringAttribute{
-							Computed: true,
+							Required:    true,
+							Description: "Gateway of the subnet.",
 						},
 						"prefix_length": schema.Int64Attribute{
-							Computed: true,
+							Required:    true,
+							Description: "Prefix length of the subnet.",
 						},
 						"subnet": schema.StringAttribute{
-							Computed: true,
+							Required:    true,
+							Description: "Network address of the subnet, e.g. 10.0.0.0.",
 						},
 					},
 				},
@@ -129,6 +142,46 @@ func (r *objectStorageResource) Schema(_ context.Context, _ resource.SchemaReque
 
 }
 
+// ValidateConfig checks that every allowed subnet is a valid network with its gateway inside it.
+func (r *objectStorageResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config objectStorageResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || config.SecurityGroups.IsNull() || config.SecurityGroups.IsUnknown() {
+		return
+	}
+
+	secGroups := []models.NetworkSecurityGroup{}
+	resp.Diagnostics.Append(config.SecurityGroups.ElementsAs(ctx, &secGroups, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, sg := range secGroups {
+		if sg.Subnet.IsUnknown() || sg.PrefixLength.IsUnknown() || sg.Gateway.IsUnknown() {
+			continue
+		}
+		sgPath := path.Root("security_groups").AtListIndex(i)
+		cidr := fmt.Sprintf("%s/%d", sg.Subnet.ValueString(), sg.PrefixLength.ValueInt64())
+		ip, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(sgPath, "Invalid security group subnet",
+				fmt.Sprintf("%q is not a valid subnet: %v", cidr, err))
+			continue
+		}
+		if !ip.Equal(network.IP) {
+			resp.Diagnostics.AddAttributeError(sgPath.AtName("subnet"), "Invalid security group subnet",
+				fmt.Sprintf("%q is not the network address of %s", sg.Subnet.ValueString(), network.String()))
+		}
+		gateway := net.ParseIP(sg.Gateway.ValueString())
+		if gateway == nil || !network.Contains(gateway) {
+			resp.Diagnostics.AddAttributeError(sgPath.AtName("gateway"), "Invalid security group gateway",
+				fmt.Sprintf("%q is not an IP address in %s", sg.Gateway.ValueString(), network.String()))
+		}
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *objectStorageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -149,29 +202,48 @@ func (r *objectStorageResource) Create(ctx context.Context, req resource.CreateR
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
-	inArg := itacservices.ObjectBucketCreateRequest{
-		Metadata: struct {
-			Name string "json:\"name\""
-		}{
-			Name: plan.Name.ValueString(),
-		},
-		Spec: struct {
-			Versioned    bool   "json:\"versioned\""
-			InstanceType string "json:\"instanceType\""
-		}{
-			Versioned:    plan.Versioned.ValueBool(),
-			InstanceType: "storage-object",
-		},
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	tflog.Info(ctx, "making a call to IDC Service for create bucket")
-	bucket, err := r.client.CreateObjectStorageBucket(ctx, &inArg)
+	plan.Region = types.StringValue(*client.Region)
+
+	bucket, err := adoptExisting(ctx, plan.AdoptExisting, plan.Name.ValueString(), client.GetObjectBucketByName)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating order",
-			"Could not create order, unexpected error: "+err.Error(),
+			"Could not look up existing bucket "+plan.Name.ValueString()+": "+err.Error(),
 		)
 		return
 	}
+	if bucket != nil {
+		tflog.Info(ctx, "adopting existing bucket", map[string]any{"ID": bucket.Metadata.ResourceId})
+	} else {
+		inArg := itacservices.ObjectBucketCreateRequest{
+			Metadata: struct {
+				Name string "json:\"name\""
+			}{
+				Name: plan.Name.ValueString(),
+			},
+			Spec: struct {
+				Versioned    bool   "json:\"versioned\""
+				InstanceType string "json:\"instanceType\""
+			}{
+				Versioned:    plan.Versioned.ValueBool(),
+				InstanceType: "storage-object",
+			},
+		}
+		tflog.Info(ctx, "making a call to IDC Service for create bucket")
+		bucket, err = client.CreateObjectStorageBucket(ctx, &inArg)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating order",
+				"Could not create order, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
 
 	plan.Cloudaccount = types.StringValue(bucket.Metadata.Cloudaccount)
 	plan.ID = types.StringValue(bucket.Metadata.ResourceId)
@@ -179,16 +251,27 @@ func (r *objectStorageResource) Create(ctx context.Context, req resource.CreateR
 	plan.PrivateEndpoint = types.StringValue(bucket.Status.Cluster.AccessEndpoint)
 	plan.Size = types.StringValue(bucket.Spec.Request.Size)
 
-	secGroups := []models.NetworkSecurityGroup{}
-	for _, sg := range bucket.Status.SecurityGroups.NetworkFilterAllow {
-		newSg := models.NetworkSecurityGroup{
-			Gateway:      types.StringValue(sg.Gateway),
-			PrefixLength: types.Int64Value(int64(sg.PrefixLength)),
-			Subnet:       types.StringValue(sg.Subnet),
+	if !plan.SecurityGroups.IsUnknown() {
+		filters, diags := objectBucketNetworkFiltersFromModel(ctx, plan.SecurityGroups)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tflog.Info(ctx, "making a call to IDC Service to update bucket security group", map[string]any{"ID": plan.ID.ValueString()})
+		bucket, err = client.UpdateObjectBucketSecurityGroup(ctx, plan.ID.ValueString(), filters)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating IDC Object Bucket security group",
+				"Could not update security group of IDC Object Bucket resource ID "+plan.ID.ValueString()+": "+err.Error(),
+			)
+			// the bucket exists, keep it in the state
+			plan.SecurityGroups = types.ListNull(types.ObjectType{}.WithAttributeTypes(models.NetworkSecurityGroupAttributes))
+			resp.State.Set(ctx, plan)
+			return
 		}
-		secGroups = append(secGroups, newSg)
 	}
-	plan.SecurityGroups, diags = types.ListValueFrom(ctx, types.ObjectType{}.WithAttributeTypes(models.NetworkSecurityGroupAttributes), secGroups)
+
+	plan.SecurityGroups, diags = objectBucketSecurityGroupsFromAPI(ctx, bucket)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -229,8 +312,20 @@ func (r *objectStorageResource) Read(ctx context.Context, req resource.ReadReque
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
 
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Region = types.StringValue(*client.Region)
+
 	// Get refreshed order value from IDC Service
-	bucket, err := r.client.GetObjectBucketByResourceId(ctx, state.ID.ValueString())
+	bucket, err := client.GetObjectBucketByResourceId(ctx, state.ID.ValueString())
+	if common.IsNotFound(err) {
+		tflog.Warn(ctx, "object bucket not found, removing from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading IDC Object Bucket resource",
@@ -248,16 +343,7 @@ func (r *objectStorageResource) Read(ctx context.Context, req resource.ReadReque
 	state.Status = types.StringValue(mapObjectBucketStatus(bucket.Status.Phase))
 	state.PrivateEndpoint = types.StringValue(bucket.Status.Cluster.AccessEndpoint)
 
-	secGroups := []models.NetworkSecurityGroup{}
-	for _, sg := range bucket.Status.SecurityGroups.NetworkFilterAllow {
-		newSg := models.NetworkSecurityGroup{
-			Gateway:      types.StringValue(sg.Gateway),
-			PrefixLength: types.Int64Value(int64(sg.PrefixLength)),
-			Subnet:       types.StringValue(sg.Subnet),
-		}
-		secGroups = append(secGroups, newSg)
-	}
-	state.SecurityGroups, diags = types.ListValueFrom(ctx, types.ObjectType{}.WithAttributeTypes(models.NetworkSecurityGroupAttributes), secGroups)
+	state.SecurityGroups, diags = objectBucketSecurityGroupsFromAPI(ctx, bucket)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -273,11 +359,106 @@ func (r *objectStorageResource) Read(ctx context.Context, req resource.ReadReque
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *objectStorageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state objectStorageResourceModel
+
+	// Retrieve the desired configuration from the plan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// use timeouts if requested by the user
+	updateTimeout, err := plan.Timeouts.GetTimeouts(ObjectStorageResourceName)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", "Could not parse update timeout: "+err.Error())
+	}
+	// Use the timeout context
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Region = types.StringValue(*client.Region)
+
+	// computed attributes are not changed by an update
+	plan.ID = state.ID
+	plan.Cloudaccount = state.Cloudaccount
+	plan.Size = state.Size
+	plan.Status = state.Status
+	plan.PrivateEndpoint = state.PrivateEndpoint
+
+	if plan.SecurityGroups.IsUnknown() || plan.SecurityGroups.Equal(state.SecurityGroups) {
+		tflog.Info(ctx, "no change detected in bucket security group, skipping update", map[string]any{"ID": state.ID.ValueString()})
+		plan.SecurityGroups = state.SecurityGroups
+	} else {
+		filters, diags := objectBucketNetworkFiltersFromModel(ctx, plan.SecurityGroups)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		tflog.Info(ctx, "making a call to IDC Service to update bucket security group", map[string]any{"ID": state.ID.ValueString()})
+		bucket, err := client.UpdateObjectBucketSecurityGroup(ctx, state.ID.ValueString(), filters)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating IDC Object Bucket security group",
+				"Could not update security group of IDC Object Bucket resource ID "+state.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		plan.SecurityGroups, diags = objectBucketSecurityGroupsFromAPI(ctx, bucket)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 func (r *objectStorageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	scope, diags := resolveImportScope(ctx, r.client, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Accept either the resource ID or the name of the bucket
+	id, err := resolveImportIDOrName(ctx, "bucket", scope.id,
+		func(ctx context.Context, id string) error {
+			_, err := scope.client.GetObjectBucketByResourceId(ctx, id)
+			return err
+		},
+		func(ctx context.Context, name string) (string, error) {
+			bucket, err := scope.client.GetObjectBucketByName(ctx, name)
+			if err != nil {
+				return "", err
+			}
+			return bucket.Metadata.ResourceId, nil
+		})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import IDC Object Storage Bucket resource", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(scope.setState(ctx, &resp.State, path.Root("cloudaccount"))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -298,8 +479,14 @@ func (r *objectStorageResource) Delete(ctx context.Context, req resource.DeleteR
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
 
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the order from IDC Services
-	err = r.client.DeleteBucketByResourceId(ctx, state.ID.ValueString())
+	err = client.DeleteBucketByResourceId(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting IDC Object Storage Bucket resource",
@@ -307,6 +494,12 @@ func (r *objectStorageResource) Delete(ctx context.Context, req resource.DeleteR
 		)
 		return
 	}
+	if err := client.WaitForBucketDeleted(ctx, state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting IDC Object Storage Bucket resource",
+			"Could not delete IDC Object Storage Bucket resource ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+	}
 }
 
 func mapObjectBucketStatus(fsStatus string) string {
@@ -325,3 +518,34 @@ func mapObjectBucketStatus(fsStatus string) string {
 		return "unspecified"
 	}
 }
+
+func objectBucketNetworkFiltersFromModel(ctx context.Context, secGroups types.List) ([]itacservices.NetworkFilter, diag.Diagnostics) {
+	filters := []itacservices.NetworkFilter{}
+	if secGroups.IsNull() {
+		return filters, nil
+	}
+
+	sgs := []models.NetworkSecurityGroup{}
+	diags := secGroups.ElementsAs(ctx, &sgs, false)
+	for _, sg := range sgs {
+		filters = append(filters, itacservices.NetworkFilter{
+			Gateway:      sg.Gateway.ValueString(),
+			PrefixLength: int(sg.PrefixLength.ValueInt64()),
+			Subnet:       sg.Subnet.ValueString(),
+		})
+	}
+	return filters, diags
+}
+
+func objectBucketSecurityGroupsFromAPI(ctx context.Context, bucket *itacservices.ObjectBucket) (types.List, diag.Diagnostics) {
+	secGroups := []models.NetworkSecurityGroup{}
+	for _, sg := range bucket.Status.SecurityGroups.NetworkFilterAllow {
+		newSg := models.NetworkSecurityGroup{
+			Gateway:      types.StringValue(sg.Gateway),
+			PrefixLength: types.Int64Value(int64(sg.PrefixLength)),
+			Subnet:       types.StringValue(sg.Subnet),
+		}
+		secGroups = append(secGroups, newSg)
+	}
+	return types.ListValueFrom(ctx, types.ObjectType{}.WithAttributeTypes(models.NetworkSecurityGroupAttributes), secGroups)
+}
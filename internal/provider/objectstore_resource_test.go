@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccObjectStorageResources(t *testing.T) {
+	server, providerConfig := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckDestroyed(server, "objects/buckets"),
+			testAccCheckDestroyed(server, "objects/users"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "intelcloud_object_storage_bucket" "test" {
+  name      = "tf-acc-bucket"
+  versioned = false
+}
+
+resource "intelcloud_object_storage_bucket_user" "test" {
+  name          = "tf-acc-bucket-user"
+  bucket_id     = "${intelcloud_object_storage_bucket.test.cloudaccount}-${intelcloud_object_storage_bucket.test.name}"
+  allow_actions = ["GetBucketLocation", "ListBucket"]
+  allow_policies = {
+    path_prefix = "/"
+    policies    = ["ReadBucket"]
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("intelcloud_object_storage_bucket.test", "id"),
+					resource.TestCheckResourceAttr("intelcloud_object_storage_bucket.test", "cloudaccount", server.CloudAccount),
+					resource.TestCheckResourceAttrSet("intelcloud_object_storage_bucket_user.test", "id"),
+					resource.TestCheckResourceAttrSet("intelcloud_object_storage_bucket_user.test", "access_info.access_key"),
+				),
+			},
+			{
+				ResourceName:            "intelcloud_object_storage_bucket.test",
+				ImportState:             true,
+				ImportStateId:           "tf-acc-bucket",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"adopt_existing", "timeouts"},
+			},
+		},
+	})
+}
+
+func TestAccObjectStorageBucket_Region(t *testing.T) {
+	primary, _ := testAccFakeServer(t)
+	dr, _ := testAccFakeServer(t)
+	dr.CloudAccount = "210987654321"
+
+	// the provider manages lab-region-1, the DR bucket goes to another region and account
+	providerConfig := fmt.Sprintf(`
+provider "intelcloud" {
+  region         = "lab-region-1"
+  cloudaccount   = %q
+  clientid       = %q
+  clientsecret   = %q
+  region_catalog = <<EOT
+regions:
+  - name: lab-region-1
+    api: %s
+    auth: %s
+    zones: [lab-region-1a]
+  - name: lab-region-2
+    api: %s
+    auth: %s
+    zones: [lab-region-2a]
+EOT
+}
+`, primary.CloudAccount, primary.ClientID, primary.ClientSecret, primary.URL, primary.URL, dr.URL, dr.URL)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckDestroyed(primary, "objects/buckets"),
+			testAccCheckDestroyed(dr, "objects/buckets"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "intelcloud_object_storage_bucket" "primary" {
+  name      = "tf-acc-primary"
+  versioned = false
+}
+
+resource "intelcloud_object_storage_bucket" "dr" {
+  name         = "tf-acc-dr"
+  versioned    = false
+  region       = "lab-region-2"
+  cloudaccount = "210987654321"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("intelcloud_object_storage_bucket.primary", "region", "lab-region-1"),
+					resource.TestCheckResourceAttr("intelcloud_object_storage_bucket.primary", "cloudaccount", primary.CloudAccount),
+					resource.TestCheckResourceAttr("intelcloud_object_storage_bucket.dr", "region", "lab-region-2"),
+					resource.TestCheckResourceAttr("intelcloud_object_storage_bucket.dr", "cloudaccount", "210987654321"),
+					func(*terraform.State) error {
+						if n, m := primary.Count("objects/buckets"), dr.Count("objects/buckets"); n != 1 || m != 1 {
+							return fmt.Errorf("expected one bucket per region, got %d and %d", n, m)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// the import ID names the region and account of the bucket
+				ResourceName:            "intelcloud_object_storage_bucket.dr",
+				ImportState:             true,
+				ImportStateId:           "lab-region-2/210987654321/tf-acc-dr",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"adopt_existing", "timeouts"},
+			},
+		},
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"terraform-provider-intelcloud/internal/models"
 	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -24,14 +25,16 @@ var (
 
 // objectStorageUserResourceModel maps the resource schema data.
 type objectStorageUserResourceModel struct {
-	ID            types.String     `tfsdk:"id"`
-	BucketId      types.String     `tfsdk:"bucket_id"`
-	Cloudaccount  types.String     `tfsdk:"cloudaccount"`
-	Name          types.String     `tfsdk:"name"`
-	Status        types.String     `tfsdk:"status"`
-	AllowActions  []types.String   `tfsdk:"allow_actions"`
-	AllowPolicies ObjectUserPolicy `tfsdk:"allow_policies"`
-	AccessInfo    types.Object     `tfsdk:"access_info"`
+	ID            types.String      `tfsdk:"id"`
+	BucketId      types.String      `tfsdk:"bucket_id"`
+	Cloudaccount  types.String      `tfsdk:"cloudaccount"`
+	Region        types.String      `tfsdk:"region"`
+	Name          types.String      `tfsdk:"name"`
+	Status        types.String      `tfsdk:"status"`
+	AllowActions  []types.String    `tfsdk:"allow_actions"`
+	AllowPolicies *ObjectUserPolicy `tfsdk:"allow_policies"`
+	AccessInfo    types.Object      `tfsdk:"access_info"`
+	AdoptExisting types.Bool        `tfsdk:"adopt_existing"`
 }
 
 type ObjectUserPolicy struct {
@@ -84,12 +87,15 @@ func (r *objectStorageUserResource) Schema(_ context.Context, _ resource.SchemaR
 			"name": schema.StringAttribute{
 				Required: true,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Adopt an existing bucket user with the same name instead of creating a new one.",
+			},
 			"bucket_id": schema.StringAttribute{
 				Required: true,
 			},
-			"cloudaccount": schema.StringAttribute{
-				Computed: true,
-			},
+			"cloudaccount": cloudaccountAttribute(),
+			"region":       regionAttribute(),
 			"status": schema.StringAttribute{
 				Computed: true,
 			},
@@ -132,39 +138,58 @@ func (r *objectStorageUserResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	actions := []string{}
-	for _, a := range plan.AllowActions {
-		actions = append(actions, a.ValueString())
-	}
-
-	perms := []string{}
-	for _, p := range plan.AllowPolicies.Policies {
-		perms = append(perms, p.ValueString())
-	}
-
-	bucketPolicy := []itacservices.BucketPolicy{
-		{
-			BucketId:    plan.BucketId.ValueString(),
-			Actions:     actions,
-			Permissions: perms,
-			Prefix:      plan.AllowPolicies.PathPrefix.ValueString(),
-		},
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	plan.Region = types.StringValue(*client.Region)
 
-	inArg := itacservices.ObjectUserCreateRequest{}
-	inArg.Metadata.Name = plan.Name.ValueString()
-
-	inArg.Spec = append(inArg.Spec, bucketPolicy...)
-
-	tflog.Info(ctx, "making a call to IDC Service for create bucket")
-	user, err := r.client.CreateObjectStorageUser(ctx, &inArg)
+	user, err := adoptExisting(ctx, plan.AdoptExisting, plan.Name.ValueString(), client.GetObjectUserByName)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating order",
-			"Could not create order, unexpected error: "+err.Error(),
+			"Could not look up existing bucket user "+plan.Name.ValueString()+": "+err.Error(),
 		)
 		return
 	}
+	if user != nil {
+		tflog.Info(ctx, "adopting existing bucket user", map[string]any{"ID": user.Metadata.UserId})
+	} else {
+		actions := []string{}
+		for _, a := range plan.AllowActions {
+			actions = append(actions, a.ValueString())
+		}
+
+		perms := []string{}
+		for _, p := range plan.AllowPolicies.Policies {
+			perms = append(perms, p.ValueString())
+		}
+
+		bucketPolicy := []itacservices.BucketPolicy{
+			{
+				BucketId:    plan.BucketId.ValueString(),
+				Actions:     actions,
+				Permissions: perms,
+				Prefix:      plan.AllowPolicies.PathPrefix.ValueString(),
+			},
+		}
+
+		inArg := itacservices.ObjectUserCreateRequest{}
+		inArg.Metadata.Name = plan.Name.ValueString()
+
+		inArg.Spec = append(inArg.Spec, bucketPolicy...)
+
+		tflog.Info(ctx, "making a call to IDC Service for create bucket")
+		user, err = client.CreateObjectStorageUser(ctx, &inArg)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating order",
+				"Could not create order, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
 
 	plan.Cloudaccount = types.StringValue(user.Metadata.Cloudaccount)
 	plan.ID = types.StringValue(user.Metadata.UserId)
@@ -200,8 +225,20 @@ func (r *objectStorageUserResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Region = types.StringValue(*client.Region)
+
 	// Get refreshed order value from IDC Service
-	user, err := r.client.GetObjectUserByUserId(ctx, state.ID.ValueString())
+	user, err := client.GetObjectUserByUserId(ctx, state.ID.ValueString())
+	if common.IsNotFound(err) {
+		tflog.Warn(ctx, "object bucket user not found, removing from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading IDC Object Bucket user resource",
@@ -215,10 +252,33 @@ func (r *objectStorageUserResource) Read(ctx context.Context, req resource.ReadR
 	state.Name = types.StringValue(user.Metadata.Name)
 	state.Status = types.StringValue(mapObjectUserStatus(user.Status.Phase))
 
+	// the resource manages a single bucket policy
+	if len(user.Spec) > 0 {
+		policy := user.Spec[0]
+		state.BucketId = types.StringValue(policy.BucketId)
+		state.AllowActions = []types.String{}
+		for _, a := range policy.Actions {
+			state.AllowActions = append(state.AllowActions, types.StringValue(a))
+		}
+		state.AllowPolicies = &ObjectUserPolicy{
+			PathPrefix: types.StringValue(policy.Prefix),
+			Policies:   []types.String{},
+		}
+		for _, p := range policy.Permissions {
+			state.AllowPolicies.Policies = append(state.AllowPolicies.Policies, types.StringValue(p))
+		}
+	}
+
 	creds := models.ObjectUserAccessModel{
 		AccessKey: types.StringValue(user.Status.Principal.Credentials.AccessKey),
 		SecretKey: types.StringValue(user.Status.Principal.Credentials.SecretKey),
 	}
+	// keep the known secret key when the service does not return it
+	if user.Status.Principal.Credentials.SecretKey == "" && !state.AccessInfo.IsNull() {
+		if secretKey, ok := state.AccessInfo.Attributes()["secret_key"].(types.String); ok {
+			creds.SecretKey = secretKey
+		}
+	}
 
 	state.AccessInfo, diags = types.ObjectValueFrom(ctx, creds.AttributeTypes(), creds)
 	resp.Diagnostics.Append(diags...)
@@ -239,8 +299,32 @@ func (r *objectStorageUserResource) Update(ctx context.Context, req resource.Upd
 }
 
 func (r *objectStorageUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	scope, diags := resolveImportScope(ctx, r.client, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Accept either the resource ID or the name of the bucket user
+	id, err := resolveImportIDOrName(ctx, "bucket user", scope.id,
+		func(ctx context.Context, id string) error {
+			_, err := scope.client.GetObjectUserByUserId(ctx, id)
+			return err
+		},
+		func(ctx context.Context, name string) (string, error) {
+			user, err := scope.client.GetObjectUserByName(ctx, name)
+			if err != nil {
+				return "", err
+			}
+			return user.Metadata.UserId, nil
+		})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import IDC Object Storage User resource", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(scope.setState(ctx, &resp.State, path.Root("cloudaccount"))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -253,8 +337,14 @@ func (r *objectStorageUserResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the order from IDC Services
-	err := r.client.DeleteObjectUserByResourceId(ctx, state.ID.ValueString())
+	err := client.DeleteObjectUserByResourceId(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting IDC Object Storage Bucket user resource",
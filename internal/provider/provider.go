@@ -5,32 +5,50 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"os"
+	"time"
 
 	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &idcProvider{}
+	_ provider.Provider                   = &idcProvider{}
+	_ provider.ProviderWithValidateConfig = &idcProvider{}
 )
 
 // idcProviderModel maps provider schema data to a Go type.
 type idcProviderModel struct {
-	Region       types.String `tfsdk:"region"`
-	Cloudaccount types.String `tfsdk:"cloudaccount"`
-	APIToken     types.String `tfsdk:"apitoken"`
-	ClientId     types.String `tfsdk:"clientid"`
-	ClientSecret types.String `tfsdk:"clientsecret"`
-	Endpoints    types.Object `tfsdk:"endpoints"`
+	Profile           types.String `tfsdk:"profile"`
+	Region            types.String `tfsdk:"region"`
+	Cloudaccount      types.String `tfsdk:"cloudaccount"`
+	APIToken          types.String `tfsdk:"apitoken"`
+	ClientId          types.String `tfsdk:"clientid"`
+	ClientSecret      types.String `tfsdk:"clientsecret"`
+	CredentialProcess types.String `tfsdk:"credential_process"`
+	Endpoints         types.Object `tfsdk:"endpoints"`
+	RegionCatalog     types.String `tfsdk:"region_catalog"`
+
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	NoProxy            types.String `tfsdk:"no_proxy"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	HTTPTimeouts       types.Object `tfsdk:"http_timeouts"`
 }
 
 type endpointsModel struct {
@@ -38,6 +56,13 @@ type endpointsModel struct {
 	Auth types.String `tfsdk:"auth"`
 }
 
+type httpTimeoutsModel struct {
+	Dial           types.String `tfsdk:"dial"`
+	TLSHandshake   types.String `tfsdk:"tls_handshake"`
+	ResponseHeader types.String `tfsdk:"response_header"`
+	Request        types.String `tfsdk:"request"`
+}
+
 // New is a helper function to simplify provider server and testing implementation.
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
@@ -64,24 +89,47 @@ func (p *idcProvider) Metadata(_ context.Context, _ provider.MetadataRequest, re
 // Schema defines the provider-level schema for configuration data.
 func (p *idcProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "Settings are taken from the provider attributes, then from the ITAC_* environment variables, then from the selected profile of the profile file. The credentials, clientid and clientsecret or credential_process, come from the first of these that sets any of them.",
 		Attributes: map[string]schema.Attribute{
+			"profile": schema.StringAttribute{
+				Optional: true,
+				Description: "Profile of the profile file to read region, cloudaccount, client_id, client_secret, credential_process, api_endpoint and auth_endpoint from. " +
+					"Defaults to the ITAC_PROFILE environment variable, then to the default profile when the file has one. " +
+					"The profile file is ~/.intelcloud/config, or the ITAC_CONFIG_FILE environment variable.",
+			},
 			"region": schema.StringAttribute{
+				Optional:    true,
+				Description: "Region to manage resources in, one of the regions of the region catalog unless both endpoints are set, in the configuration or the profile. Defaults to the ITAC_REGION environment variable, then to the profile.",
+			},
+			"region_catalog": schema.StringAttribute{
 				Optional: true,
+				Description: "Path of a JSON or YAML file, or the JSON or YAML content, listing regions with their `api` and `auth` endpoints and `zones`. " +
+					"Its regions are added to the built-in ones, replacing those of the same name. Defaults to the ITAC_REGION_CATALOG environment variable.",
 			},
 			"cloudaccount": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Description: "Cloud account to manage resources in. Defaults to the ITAC_CLOUDACCOUNT environment variable, then to the profile.",
 			},
 			"apitoken": schema.StringAttribute{
 				Optional: true,
 			},
 			"clientid": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Description: "Client ID to log in with. Defaults to the ITAC_CLIENT_ID environment variable, then to the profile.",
 			},
 			"clientsecret": schema.StringAttribute{
+				Optional:    true,
+				Description: "Client secret to log in with. Defaults to the ITAC_CLIENT_SECRET environment variable, then to the profile.",
+			},
+			"credential_process": schema.StringAttribute{
 				Optional: true,
+				Description: "Command printing the credentials as JSON, either `client_id` and `client_secret` or an `access_token` with its `expires_at` time. " +
+					"It is used in place of clientid and clientsecret and run again whenever the access token expires. " +
+					"Defaults to the ITAC_CREDENTIAL_PROCESS environment variable, then to the profile.",
 			},
 			"endpoints": schema.SingleNestedAttribute{
-				Optional: true,
+				Optional:    true,
+				Description: "Endpoints overriding the ones of the region. Default to the api_endpoint and auth_endpoint of the profile when the region is the one of the profile.",
 				Attributes: map[string]schema.Attribute{
 					"api": schema.StringAttribute{
 						Optional: true,
@@ -91,63 +139,146 @@ func (p *idcProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 					},
 				},
 			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "Proxy for all ITAC API requests. Defaults to the HTTPS_PROXY and HTTP_PROXY environment variables.",
+			},
+			"no_proxy": schema.StringAttribute{
+				Optional:    true,
+				Description: "Comma separated hosts, domains and CIDRs reached without the proxy. Defaults to the NO_PROXY environment variable.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM file of CA certificates trusted in addition to the system roots.",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded CA certificates trusted in addition to the system roots.",
+			},
+			"client_cert": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded client certificate for mutual TLS, or the path to a file holding it. Requires client_key.",
+			},
+			"client_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key of client_cert, or the path to a file holding it.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skip verification of the ITAC API server certificates. Only meant for testing.",
+			},
+			"http_timeouts": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Timeouts of the steps of each ITAC API request, as durations such as \"30s\".",
+				Attributes: map[string]schema.Attribute{
+					"dial": schema.StringAttribute{
+						Optional:    true,
+						Description: "Timeout for opening a connection. Defaults to 30s.",
+					},
+					"tls_handshake": schema.StringAttribute{
+						Optional:    true,
+						Description: "Timeout for the TLS handshake of a new connection. Defaults to 10s.",
+					},
+					"response_header": schema.StringAttribute{
+						Optional:    true,
+						Description: "Timeout for the response headers once a request is sent. Defaults to 60s.",
+					},
+					"request": schema.StringAttribute{
+						Optional:    true,
+						Description: "Timeout for a whole request, from opening the connection to reading the response. Defaults to the sum of the other timeouts plus 30s to read the response.",
+					},
+				},
+			},
 		},
 	}
 }
 
-// Configure prepares a HashiCups API client for data sources and resources.
-func (p *idcProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
-	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
-
-	region := os.Getenv("ITAC_REGION")
-	cloudaccount := os.Getenv("ITAC_CLOUDACCOUNT")
-	clientid := os.Getenv("ITAC_CLIENT_ID")
-	clientsecret := os.Getenv("ITAC_CLIENT_SECRET")
-
-	// Retrieve provider data from configuration
+// ValidateConfig checks that a configured region is in the region catalog, unless the
+// endpoints may be given some other way.
+func (p *idcProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
 	var config idcProviderModel
-	diags := req.Config.Get(ctx, &config)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if config.RegionCatalog.IsUnknown() {
+		return
+	}
 
-	if !config.Region.IsNull() {
-		region = config.Region.ValueString()
+	catalog, err := common.LoadRegionCatalog(regionCatalogSource(config.RegionCatalog))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("region_catalog"), "Invalid ITAC Region Catalog", err.Error())
+		return
 	}
+	if config.Region.IsNull() || config.Region.IsUnknown() {
+		return
+	}
+	if _, err := catalog.Region(config.Region.ValueString()); err != nil && !endpointsMayBeSet(ctx, &config) {
+		resp.Diagnostics.AddAttributeError(path.Root("region"), "Unknown ITAC Region", err.Error())
+	}
+}
 
-	if !config.Cloudaccount.IsNull() {
-		cloudaccount = config.Cloudaccount.ValueString()
+// endpointsMayBeSet reports whether the attributes and the profile may give both endpoints,
+// so the region need not be in the catalog. When they cannot be known yet, Configure checks
+// the region.
+func endpointsMayBeSet(ctx context.Context, config *idcProviderModel) bool {
+	if config.Profile.IsUnknown() || config.Endpoints.IsUnknown() {
+		return true
 	}
+	var endpoints endpointsModel
+	if !config.Endpoints.IsNull() {
+		if diags := config.Endpoints.As(ctx, &endpoints, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return true
+		}
+		if endpoints.API.IsUnknown() || endpoints.Auth.IsUnknown() {
+			return true
+		}
+	}
+
+	profile, diags := loadProfile(config.Profile)
+	if diags.HasError() {
+		return true
+	}
+	settings := resolveProviderSettings(config, &endpoints, profile)
+	return settings.APIEndpoint != "" && settings.AuthEndpoint != ""
+}
 
-	if !config.ClientId.IsNull() {
-		clientid = config.ClientId.ValueString()
+// Configure prepares a HashiCups API client for data sources and resources.
+func (p *idcProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	// Retrieve provider data from configuration
+	var config idcProviderModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if !config.ClientSecret.IsNull() {
-		clientsecret = config.ClientSecret.ValueString()
+	// Default values to the profile, override them with the environment
+	// variables and those with the Terraform configuration values if set.
+	profile, diags := loadProfile(config.Profile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(profile.Ignored) > 0 {
+		tflog.Warn(ctx, "ignoring unknown profile settings", map[string]any{"profile": profile.Name, "settings": profile.Ignored})
 	}
 
-	// Retrieve endpoints if set
-	var clientTokenEndpoint, serviceEndpoint string
+	var endpoints endpointsModel
 	if !config.Endpoints.IsNull() {
-		var endpoints endpointsModel
-
 		diags := config.Endpoints.As(ctx, &endpoints, basetypes.ObjectAsOptions{})
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
-
-		if !endpoints.API.IsNull() {
-			serviceEndpoint = endpoints.API.ValueString()
-		}
-		if !endpoints.Auth.IsNull() {
-			clientTokenEndpoint = endpoints.Auth.ValueString()
-		}
 	}
 
+	settings := resolveProviderSettings(&config, &endpoints, profile)
+	region, cloudaccount := settings.Region, settings.Cloudaccount
+	clientid, clientsecret, credentialProcess := settings.ClientID, settings.ClientSecret, settings.CredentialProcess
+	clientTokenEndpoint, serviceEndpoint := settings.AuthEndpoint, settings.APIEndpoint
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -156,8 +287,8 @@ func (p *idcProvider) Configure(ctx context.Context, req provider.ConfigureReque
 			path.Root("region"),
 			"Missing ITAC API Region",
 			"The provider cannot create the ITAC API client as there is a missing or empty value for the ITAC API region. "+
-				"Set the region value in the configuration or use the ITAC_REGION environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the region value in the configuration, use the ITAC_REGION environment variable or set it in the profile. "+
+				"If any is already set, ensure the value is not empty.",
 		)
 	}
 
@@ -166,40 +297,128 @@ func (p *idcProvider) Configure(ctx context.Context, req provider.ConfigureReque
 			path.Root("cloudaccount"),
 			"Missing ITAC Cloudaccount",
 			"The provider cannot create the ITAC Cloudaccount as there is a missing or empty value for the ITAC Cloudaccount. "+
-				"Set the host value in the configuration or use the ITAC_CLOUDACCOUNT environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the cloudaccount value in the configuration, use the ITAC_CLOUDACCOUNT environment variable or set it in the profile. "+
+				"If any is already set, ensure the value is not empty.",
+		)
+	}
+
+	if !config.CredentialProcess.IsNull() && (!config.ClientId.IsNull() || !config.ClientSecret.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credential_process"),
+			"Conflicting ITAC Credentials",
+			"The provider takes its credentials either from clientid and clientsecret or from credential_process. Set only one of them.",
 		)
 	}
 
-	if clientid == "" {
+	if clientid == "" && credentialProcess == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("clientid"),
 			"Missing ITAC Client Id",
 			"The provider cannot create the ITAC Client Id as there is a missing or empty value for the ITAC client id. "+
-				"Set the clientid value in the configuration or use the ITAC_CLIENT_ID environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the clientid value in the configuration, use the ITAC_CLIENT_ID environment variable or set it in the profile. "+
+				"If any is already set, ensure the value is not empty.",
 		)
 	}
 
-	if clientsecret == "" {
+	if clientsecret == "" && credentialProcess == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("clientsecret"),
 			"Missing ITAC Client secret",
 			"The provider cannot create the ITAC client secret as there is a missing or empty value for the ITAC client secret "+
-				"Set the clientsecret value in the configuration or use the ITAC_CLIENT_SECRET environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the clientsecret value in the configuration, use the ITAC_CLIENT_SECRET environment variable or set it in the profile. "+
+				"If any is already set, ensure the value is not empty.",
 		)
 	}
 
+	catalog, err := common.LoadRegionCatalog(regionCatalogSource(config.RegionCatalog))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("region_catalog"), "Invalid ITAC Region Catalog", err.Error())
+	}
+
+	if config.ClientCert.IsNull() != config.ClientKey.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_cert"),
+			"Incomplete ITAC Client Certificate",
+			"The provider cannot use a client certificate without its key. Set both client_cert and client_key, or neither.",
+		)
+	}
+
+	transportConfig := common.TransportConfig{
+		ProxyURL:           config.ProxyURL.ValueString(),
+		NoProxy:            config.NoProxy.ValueString(),
+		CACertFile:         config.CACertFile.ValueString(),
+		CACertPEM:          config.CACertPEM.ValueString(),
+		ClientCert:         config.ClientCert.ValueString(),
+		ClientKey:          config.ClientKey.ValueString(),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+	}
+	if !config.HTTPTimeouts.IsNull() {
+		var timeouts httpTimeoutsModel
+		resp.Diagnostics.Append(config.HTTPTimeouts.As(ctx, &timeouts, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, t := range []struct {
+			name  string
+			value types.String
+			dst   *time.Duration
+		}{
+			{"dial", timeouts.Dial, &transportConfig.DialTimeout},
+			{"tls_handshake", timeouts.TLSHandshake, &transportConfig.TLSHandshakeTimeout},
+			{"response_header", timeouts.ResponseHeader, &transportConfig.ResponseHeaderTimeout},
+			{"request", timeouts.Request, &transportConfig.RequestTimeout},
+		} {
+			if t.value.IsNull() {
+				continue
+			}
+			d, err := time.ParseDuration(t.value.ValueString())
+			if err != nil || d <= 0 {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("http_timeouts").AtName(t.name),
+					"Invalid ITAC HTTP Timeout",
+					"The http_timeouts."+t.name+" value must be a positive duration such as \"30s\", got \""+t.value.ValueString()+"\".",
+				)
+				continue
+			}
+			*t.dst = d
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if clientTokenEndpoint == "" || serviceEndpoint == "" {
-		clientTokenEndpoint, serviceEndpoint = discoverITACServiceEndpoint(region)
+
+	httpClient, err := common.NewHTTPClient(transportConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ITAC HTTP Transport Configuration",
+			"The provider cannot build the HTTP transport from the proxy and TLS settings: "+err.Error(),
+		)
+		return
+	}
+
+	// a region the catalog does not list is usable when both endpoints are given
+	if serviceEndpoint == "" || clientTokenEndpoint == "" {
+		regionInfo, err := catalog.Region(region)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("region"), "Unknown ITAC Region", err.Error())
+			return
+		}
+		if serviceEndpoint == "" {
+			serviceEndpoint = regionInfo.APIEndpoint
+		}
+		if clientTokenEndpoint == "" {
+			clientTokenEndpoint = regionInfo.AuthEndpoint
+		}
 	}
 
 	// Create a new HashiCups client using the configuration values
-	client, err := itacservices.NewClient(ctx, &serviceEndpoint, &clientTokenEndpoint, &cloudaccount, &clientid, &clientsecret, &region)
+	var client *itacservices.IDCServicesClient
+	if credentialProcess != "" {
+		client, err = itacservices.NewClientWithCredentialProcess(ctx, &serviceEndpoint, &clientTokenEndpoint, &cloudaccount, &region, credentialProcess, httpClient)
+	} else {
+		client, err = itacservices.NewClient(ctx, &serviceEndpoint, &clientTokenEndpoint, &cloudaccount, &clientid, &clientsecret, &region, httpClient)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create ITAC API Client",
@@ -209,6 +428,7 @@ func (p *idcProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		)
 		return
 	}
+	client.Regions = catalog
 
 	// Make the HashiCups client available during DataSource and Resource
 	// type Configure methods.
@@ -226,6 +446,8 @@ func (p *idcProvider) DataSources(_ context.Context) []func() datasource.DataSou
 		NewMachineImagesDataSource,
 		// NewKubernetesDataSource,
 		NewKubeconfigDataSource,
+		NewIKSVersionsDataSource,
+		NewRegionsDataSource,
 	}
 }
 
@@ -238,24 +460,106 @@ func (p *idcProvider) Resources(_ context.Context) []func() resource.Resource {
 		NewIKSClusterResource,
 		NewIKSNodeGroupResource,
 		NewIKSLBResource,
+		NewLoadBalancerResource,
+		NewFirewallRuleResource,
 		NewObjectStorageResource,
 		NewObjectStorageUserResource,
 	}
 }
 
-func discoverITACServiceEndpoint(region string) (string, string) {
-	switch region {
-	case "us-region-1":
-		return "https://client-token.api.idcservice.net", "https://us-region-1-sdk-api.cloud.intel.com"
-	case "us-region-2":
-		return "https://client-token.api.idcservice.net", "https://us-region-2-sdk-api.cloud.intel.com"
-	case "us-region-3":
-		return "https://client-token.api.idcservice.net", "https://us-region-3-sdk-api.cloud.intel.com"
-	case "us-region-4":
-		return "https://client-token.api.idcservice.net", "https://us-region-4-sdk-api.cloud.intel.com"
-	case "us-region-5":
-		return "https://client-token.api.idcservice.net", "https://us-region-5-sdk-api.cloud.intel.com"
-	default:
-		return "", ""
+// regionCatalogSource returns the region catalog override of the configuration, falling
+// back to the ITAC_REGION_CATALOG environment variable.
+func regionCatalogSource(value types.String) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+	return os.Getenv("ITAC_REGION_CATALOG")
+}
+
+// resolveProviderSettings takes each setting from the attributes, then from the environment,
+// then from the profile. The credentials are taken as a whole, either the client id and secret
+// or the credential process of the first of these setting any. The profile endpoints belong
+// to the profile region and are only used when the provider ends up in that region, other
+// regions get theirs from the catalog.
+func resolveProviderSettings(config *idcProviderModel, endpoints *endpointsModel, profile *common.Profile) common.Profile {
+	settings := common.Profile{
+		Name:         profile.Name,
+		Region:       stringOr(config.Region, envOr("ITAC_REGION", profile.Region)),
+		Cloudaccount: stringOr(config.Cloudaccount, envOr("ITAC_CLOUDACCOUNT", profile.Cloudaccount)),
+	}
+
+	// all credentials come from the first source setting any, so a client id of one source is
+	// never paired with the secret or credential process of another
+	sources := []common.Profile{
+		{ClientID: stringOr(config.ClientId, ""), ClientSecret: stringOr(config.ClientSecret, ""), CredentialProcess: stringOr(config.CredentialProcess, "")},
+		{ClientID: os.Getenv("ITAC_CLIENT_ID"), ClientSecret: os.Getenv("ITAC_CLIENT_SECRET"), CredentialProcess: os.Getenv("ITAC_CREDENTIAL_PROCESS")},
+		{ClientID: profile.ClientID, ClientSecret: profile.ClientSecret, CredentialProcess: profile.CredentialProcess},
+	}
+	for _, source := range sources {
+		if source.ClientID != "" || source.ClientSecret != "" || source.CredentialProcess != "" {
+			settings.ClientID, settings.ClientSecret = source.ClientID, source.ClientSecret
+			settings.CredentialProcess = source.CredentialProcess
+			break
+		}
+	}
+
+	if settings.Region == profile.Region {
+		settings.APIEndpoint, settings.AuthEndpoint = profile.APIEndpoint, profile.AuthEndpoint
+	}
+	settings.APIEndpoint = stringOr(endpoints.API, settings.APIEndpoint)
+	settings.AuthEndpoint = stringOr(endpoints.Auth, settings.AuthEndpoint)
+	return settings
+}
+
+// loadProfile reads the profile selected by the profile attribute or the ITAC_PROFILE
+// environment variable. Without a selection the default profile is used when there is one,
+// otherwise an empty profile.
+func loadProfile(value types.String) (*common.Profile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	name := envOr("ITAC_PROFILE", "")
+	if !value.IsNull() {
+		name = value.ValueString()
+	}
+	selected := name != ""
+	if !selected {
+		name = common.DefaultProfileName
+	}
+
+	file := os.Getenv("ITAC_CONFIG_FILE")
+	if file == "" {
+		var err error
+		if file, err = common.DefaultProfileFile(); err != nil {
+			if selected {
+				diags.AddAttributeError(path.Root("profile"), "Invalid ITAC Profile", err.Error())
+			}
+			return &common.Profile{}, diags
+		}
+	}
+
+	profile, err := common.LoadProfile(file, name)
+	if err != nil {
+		if !selected && (errors.Is(err, os.ErrNotExist) || errors.Is(err, common.ErrProfileNotFound)) {
+			return &common.Profile{}, diags
+		}
+		diags.AddAttributeError(path.Root("profile"), "Invalid ITAC Profile", err.Error())
+		return nil, diags
+	}
+	return profile, diags
+}
+
+// envOr returns the value of the environment variable, or fallback when it is not set.
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// stringOr returns the value of an attribute, or fallback when it is not set.
+func stringOr(value types.String, fallback string) string {
+	if value.IsNull() {
+		return fallback
 	}
+	return value.ValueString()
 }
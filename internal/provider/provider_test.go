@@ -3,16 +3,312 @@
 
 package provider
 
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"terraform-provider-intelcloud/pkg/fakeitac"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
 // acceptance testing. The factory function will be invoked for every Terraform
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
-//var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-//	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
-//}
-
-/* func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
-} */
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"intelcloud": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccFakeServer starts a fake ITAC API for the test and returns it together with
+// a provider block whose endpoints point at it, so acceptance tests run offline.
+func testAccFakeServer(t *testing.T) (*fakeitac.Server, string) {
+	server := fakeitac.NewServer(t)
+
+	config := fmt.Sprintf(`
+provider "intelcloud" {
+  region       = "us-region-1"
+  cloudaccount = %q
+  clientid     = %q
+  clientsecret = %q
+  endpoints = {
+    api  = %q
+    auth = %q
+  }
+}
+`, server.CloudAccount, server.ClientID, server.ClientSecret, server.URL, server.URL)
+
+	return server, config
+}
+
+// testAccCheckDestroyed returns a CheckDestroy func verifying the fake has no resources
+// left in collection.
+func testAccCheckDestroyed(server *fakeitac.Server, collection string) func(*terraform.State) error {
+	return func(*terraform.State) error {
+		if n := server.Count(collection); n != 0 {
+			return fmt.Errorf("%d %s still exist after destroy", n, collection)
+		}
+		return nil
+	}
+}
+
+// testAccProfileFile writes a profile file with a lab profile for the fake server and
+// points ITAC_CONFIG_FILE at it. The ITAC_* settings of the environment are cleared.
+func testAccProfileFile(t *testing.T, server *fakeitac.Server) {
+	file := filepath.Join(t.TempDir(), "config")
+	content := fmt.Sprintf(`# written by the test
+[default]
+region = us-region-5
+
+[profile lab]
+region        = us-region-2
+cloudaccount  = %s
+client_id     = %s
+client_secret = "%s"
+api_endpoint  = %s
+auth_endpoint = %s
+`, server.CloudAccount, server.ClientID, server.ClientSecret, server.URL, server.URL)
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("ITAC_CONFIG_FILE", file)
+	for _, key := range []string{"ITAC_PROFILE", "ITAC_REGION", "ITAC_CLOUDACCOUNT", "ITAC_CLIENT_ID", "ITAC_CLIENT_SECRET"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestAccProvider_Profile(t *testing.T) {
+	server := fakeitac.NewServer(t)
+	testAccProfileFile(t, server)
+	t.Setenv("ITAC_PROFILE", "lab")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "intelcloud" {}
+
+data "intelcloud_regions" "all" {}
+`,
+				Check: resource.TestCheckResourceAttr("data.intelcloud_regions.all", "current", "us-region-2"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_ProfilePrecedence(t *testing.T) {
+	server := fakeitac.NewServer(t)
+	testAccProfileFile(t, server)
+	// the environment overrides the profile, the attributes override both
+	t.Setenv("ITAC_PROFILE", "default")
+	t.Setenv("ITAC_REGION", "us-region-3")
+	// the lab profile endpoints are for us-region-2, the other regions use the catalog ones
+	t.Setenv("ITAC_REGION_CATALOG", fmt.Sprintf(`{"regions": [
+  {"name": "us-region-3", "api": %[1]q, "auth": %[1]q, "zones": ["us-region-3a"]},
+  {"name": "us-region-4", "api": %[1]q, "auth": %[1]q, "zones": ["us-region-4a"]}
+]}`, server.URL))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "intelcloud" {
+  profile = "lab"
+}
+
+data "intelcloud_regions" "all" {}
+`,
+				Check: resource.TestCheckResourceAttr("data.intelcloud_regions.all", "current", "us-region-3"),
+			},
+			{
+				Config: `
+provider "intelcloud" {
+  profile = "lab"
+  region  = "us-region-4"
+}
+
+data "intelcloud_regions" "all" {}
+`,
+				Check: resource.TestCheckResourceAttr("data.intelcloud_regions.all", "current", "us-region-4"),
+			},
+		},
+	})
+}
+
+func TestResolveProviderSettings(t *testing.T) {
+	profile := &common.Profile{
+		Name:         "lab",
+		Region:       "us-region-2",
+		Cloudaccount: "111111111111",
+		ClientID:     "profile-id",
+		ClientSecret: "profile-secret",
+		APIEndpoint:  "https://api.lab.example",
+		AuthEndpoint: "https://auth.lab.example",
+	}
+
+	tests := []struct {
+		name      string
+		env       map[string]string
+		config    idcProviderModel
+		endpoints endpointsModel
+		want      common.Profile
+	}{
+		{
+			name: "profile",
+			want: common.Profile{
+				Name: "lab", Region: "us-region-2", Cloudaccount: "111111111111", ClientID: "profile-id", ClientSecret: "profile-secret",
+				APIEndpoint: "https://api.lab.example", AuthEndpoint: "https://auth.lab.example",
+			},
+		},
+		{
+			name: "environment region",
+			env:  map[string]string{"ITAC_REGION": "us-region-3", "ITAC_CLIENT_ID": "env-id"},
+			want: common.Profile{Name: "lab", Region: "us-region-3", Cloudaccount: "111111111111", ClientID: "env-id"},
+		},
+		{
+			name:   "attribute region",
+			env:    map[string]string{"ITAC_REGION": "us-region-3"},
+			config: idcProviderModel{Region: types.StringValue("us-region-4"), Cloudaccount: types.StringValue("222222222222")},
+			want:   common.Profile{Name: "lab", Region: "us-region-4", Cloudaccount: "222222222222", ClientID: "profile-id", ClientSecret: "profile-secret"},
+		},
+		{
+			name:   "attribute region of the profile",
+			env:    map[string]string{"ITAC_REGION": "us-region-3"},
+			config: idcProviderModel{Region: types.StringValue("us-region-2")},
+			want: common.Profile{
+				Name: "lab", Region: "us-region-2", Cloudaccount: "111111111111", ClientID: "profile-id", ClientSecret: "profile-secret",
+				APIEndpoint: "https://api.lab.example", AuthEndpoint: "https://auth.lab.example",
+			},
+		},
+		{
+			name:      "attribute endpoint",
+			env:       map[string]string{"ITAC_REGION": "us-region-3"},
+			endpoints: endpointsModel{API: types.StringValue("https://api.other.example")},
+			want: common.Profile{
+				Name: "lab", Region: "us-region-3", Cloudaccount: "111111111111", ClientID: "profile-id", ClientSecret: "profile-secret",
+				APIEndpoint: "https://api.other.example",
+			},
+		},
+		{
+			name:   "attribute client id over environment credentials",
+			env:    map[string]string{"ITAC_REGION": "us-region-3", "ITAC_CREDENTIAL_PROCESS": "vault-credentials", "ITAC_CLIENT_SECRET": "env-secret"},
+			config: idcProviderModel{ClientId: types.StringValue("attr-id")},
+			want:   common.Profile{Name: "lab", Region: "us-region-3", Cloudaccount: "111111111111", ClientID: "attr-id"},
+		},
+		{
+			name:   "attribute client id without the profile secret",
+			env:    map[string]string{"ITAC_REGION": "us-region-3"},
+			config: idcProviderModel{ClientId: types.StringValue("attr-id")},
+			want:   common.Profile{Name: "lab", Region: "us-region-3", Cloudaccount: "111111111111", ClientID: "attr-id"},
+		},
+		{
+			name:   "attribute credential process over environment client id",
+			env:    map[string]string{"ITAC_REGION": "us-region-3", "ITAC_CLIENT_ID": "env-id", "ITAC_CLIENT_SECRET": "env-secret"},
+			config: idcProviderModel{CredentialProcess: types.StringValue("vault-credentials")},
+			want:   common.Profile{Name: "lab", Region: "us-region-3", Cloudaccount: "111111111111", CredentialProcess: "vault-credentials"},
+		},
+		{
+			name: "environment credential process over profile client id",
+			env:  map[string]string{"ITAC_REGION": "us-region-3", "ITAC_CREDENTIAL_PROCESS": "vault-credentials"},
+			want: common.Profile{Name: "lab", Region: "us-region-3", Cloudaccount: "111111111111", CredentialProcess: "vault-credentials"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"ITAC_REGION", "ITAC_CLOUDACCOUNT", "ITAC_CLIENT_ID", "ITAC_CLIENT_SECRET", "ITAC_CREDENTIAL_PROCESS"} {
+				t.Setenv(key, tt.env[key])
+			}
+			if got := resolveProviderSettings(&tt.config, &tt.endpoints, profile); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccProvider_UnknownProfile(t *testing.T) {
+	testAccProfileFile(t, fakeitac.NewServer(t))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "intelcloud" {
+  profile = "staging"
+}
+
+data "intelcloud_regions" "all" {}
+`,
+				ExpectError: regexp.MustCompile(`no profile "staging"`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_CredentialProcess(t *testing.T) {
+	server := fakeitac.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(server, "sshpublickeys"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "intelcloud" {
+  region             = "us-region-1"
+  cloudaccount       = %q
+  credential_process = "echo '{\"client_id\": \"%s\", \"client_secret\": \"%s\"}'"
+  endpoints = {
+    api  = %q
+    auth = %q
+  }
+}
+
+resource "intelcloud_sshkey" "test" {
+  metadata = {
+    name = "tf-acc-process"
+  }
+  spec = {
+    generate_key_type = "ed25519"
+  }
+}
+`, server.CloudAccount, server.ClientID, server.ClientSecret, server.URL, server.URL),
+				Check: resource.TestCheckResourceAttrSet("intelcloud_sshkey.test", "metadata.resourceid"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_ConflictingCredentials(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "intelcloud" {
+  region             = "us-region-1"
+  cloudaccount       = "123456789012"
+  clientid           = "id"
+  clientsecret       = "secret"
+  credential_process = "vault-credentials"
+}
+
+data "intelcloud_regions" "all" {}
+`,
+				ExpectError: regexp.MustCompile(`Conflicting ITAC Credentials`),
+			},
+		},
+	})
+}
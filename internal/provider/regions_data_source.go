@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewRegionsDataSource() datasource.DataSource {
+	return &regionsDataSource{}
+}
+
+type regionsDataSource struct {
+	client *itacservices.IDCServicesClient
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &regionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &regionsDataSource{}
+)
+
+// regionsDataSourceModel maps the data source schema data.
+type regionsDataSourceModel struct {
+	Current types.String  `tfsdk:"current"`
+	Regions []regionModel `tfsdk:"regions"`
+}
+
+type regionModel struct {
+	Name         types.String   `tfsdk:"name"`
+	APIEndpoint  types.String   `tfsdk:"api_endpoint"`
+	AuthEndpoint types.String   `tfsdk:"auth_endpoint"`
+	Zones        []types.String `tfsdk:"zones"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *regionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*itacservices.IDCServicesClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *itacservices.IDCServicesClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *regionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_regions"
+}
+
+func (d *regionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the regions of the region catalog with their endpoints and availability zones.",
+		Attributes: map[string]schema.Attribute{
+			"current": schema.StringAttribute{
+				Computed:    true,
+				Description: "Region the provider is configured for.",
+			},
+			"regions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Regions of the catalog, sorted by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"api_endpoint": schema.StringAttribute{
+							Computed: true,
+						},
+						"auth_endpoint": schema.StringAttribute{
+							Computed: true,
+						},
+						"zones": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Availability zones of the region, the default zone first. The built-in regions only list their default zone and accept any zone named after the region, such as us-region-2b.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *regionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state regionsDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	catalog := d.client.Regions
+	if catalog == nil {
+		catalog = common.DefaultRegionCatalog()
+	}
+
+	state.Current = types.StringValue(*d.client.Region)
+	state.Regions = []regionModel{}
+	for _, r := range catalog.Regions() {
+		region := regionModel{
+			Name:         types.StringValue(r.Name),
+			APIEndpoint:  types.StringValue(r.APIEndpoint),
+			AuthEndpoint: types.StringValue(r.AuthEndpoint),
+			Zones:        []types.String{},
+		}
+		for _, zone := range r.Zones {
+			region.Zones = append(region.Zones, types.StringValue(zone))
+		}
+		state.Regions = append(state.Regions, region)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"terraform-provider-intelcloud/pkg/fakeitac"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRegionsDataSource(t *testing.T) {
+	_, providerConfig := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "intelcloud_regions" "all" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.intelcloud_regions.all", "current", "us-region-1"),
+					resource.TestCheckResourceAttr("data.intelcloud_regions.all", "regions.#", "5"),
+					resource.TestCheckResourceAttr("data.intelcloud_regions.all", "regions.0.name", "us-region-1"),
+					resource.TestCheckResourceAttr("data.intelcloud_regions.all", "regions.0.zones.0", "us-region-1a"),
+					resource.TestCheckResourceAttr("data.intelcloud_regions.all", "regions.1.api_endpoint", "https://us-region-2-sdk-api.cloud.intel.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRegionsDataSource_Catalog(t *testing.T) {
+	server, _ := testAccFakeServer(t)
+
+	// the catalog points a new region at the fake server, so no endpoints are configured
+	providerConfig := fmt.Sprintf(`
+provider "intelcloud" {
+  region         = "lab-region-1"
+  cloudaccount   = %q
+  clientid       = %q
+  clientsecret   = %q
+  region_catalog = <<EOT
+regions:
+  - name: lab-region-1
+    api: %s
+    auth: %s
+    zones: [lab-region-1b, lab-region-1a]
+EOT
+}
+`, server.CloudAccount, server.ClientID, server.ClientSecret, server.URL, server.URL)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "intelcloud_regions" "all" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.intelcloud_regions.all", "current", "lab-region-1"),
+					resource.TestCheckResourceAttr("data.intelcloud_regions.all", "regions.#", "6"),
+					resource.TestCheckResourceAttr("data.intelcloud_regions.all", "regions.0.name", "lab-region-1"),
+					resource.TestCheckResourceAttr("data.intelcloud_regions.all", "regions.0.zones.0", "lab-region-1b"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_UnknownRegion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "intelcloud" {
+  region       = "us-region-9"
+  cloudaccount = "123456789012"
+  clientid     = "id"
+  clientsecret = "secret"
+}
+
+data "intelcloud_regions" "all" {}
+`,
+				ExpectError: regexp.MustCompile(`unknown\s+region\s+"us-region-9"`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_UnknownRegionWithEndpoints(t *testing.T) {
+	server, _ := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "intelcloud" {
+  region       = "eu-test-1"
+  cloudaccount = %q
+  clientid     = %q
+  clientsecret = %q
+  endpoints = {
+    api  = %q
+    auth = %q
+  }
+}
+
+data "intelcloud_regions" "all" {}
+`, server.CloudAccount, server.ClientID, server.ClientSecret, server.URL, server.URL),
+				Check: resource.TestCheckResourceAttr("data.intelcloud_regions.all", "current", "eu-test-1"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_UnknownRegionWithProfileEndpoints(t *testing.T) {
+	server := fakeitac.NewServer(t)
+	file := filepath.Join(t.TempDir(), "config")
+	content := fmt.Sprintf(`[profile lab]
+region        = eu-test-1
+cloudaccount  = %s
+client_id     = %s
+client_secret = "%s"
+api_endpoint  = %s
+auth_endpoint = %s
+`, server.CloudAccount, server.ClientID, server.ClientSecret, server.URL, server.URL)
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ITAC_CONFIG_FILE", file)
+	for _, key := range []string{"ITAC_PROFILE", "ITAC_REGION", "ITAC_CLOUDACCOUNT", "ITAC_CLIENT_ID", "ITAC_CLIENT_SECRET"} {
+		t.Setenv(key, "")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "intelcloud" {
+  profile = "lab"
+  region  = "eu-test-1"
+}
+
+data "intelcloud_regions" "all" {}
+`,
+				Check: resource.TestCheckResourceAttr("data.intelcloud_regions.all", "current", "eu-test-1"),
+			},
+		},
+	})
+}
@@ -27,6 +27,7 @@ var (
 
 // storagesDataSourceModel maps the data source schema data.
 type sshkeysDataSourceModel struct {
+	Name    types.String  `tfsdk:"name"`
 	SSHKeys []sshkeyModel `tfsdk:"sshkeys"`
 }
 
@@ -73,6 +74,10 @@ func (d *sshkeysDataSource) Metadata(_ context.Context, req datasource.MetadataR
 func (d *sshkeysDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the key with this name.",
+			},
 			"sshkeys": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -115,19 +120,39 @@ func (d *sshkeysDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 func (d *sshkeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
 	var state sshkeysDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state.SSHKeys = []sshkeyModel{}
 
-	sshkeyList, err := d.client.GetSSHKeys(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read IDC Compute SSHKeys",
-			err.Error(),
-		)
-		return
+	sshkeys := []itacservices.SSHKey{}
+	if !state.Name.IsNull() {
+		key, err := d.client.GetSSHKeyByName(ctx, state.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read IDC Compute SSHKey "+state.Name.ValueString(),
+				err.Error(),
+			)
+			return
+		}
+		sshkeys = append(sshkeys, *key)
+	} else {
+		sshkeyList, err := d.client.GetSSHKeys(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read IDC Compute SSHKeys",
+				err.Error(),
+			)
+			return
+		}
+		sshkeys = sshkeyList.SSHKey
 	}
-	for _, key := range sshkeyList.SSHKey {
+	for _, key := range sshkeys {
 		sshkeyModel := sshkeyModel{
 			Metadata: resourceMetadata{
+				ResourceId:   types.StringValue(key.Metadata.ResourceId),
 				Cloudaccount: types.StringValue(key.Metadata.Cloudaccount),
 				Name:         types.StringValue(key.Metadata.Name),
 			},
@@ -140,7 +165,7 @@ func (d *sshkeysDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -3,26 +3,54 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &sshKeyResource{}
-	_ resource.ResourceWithConfigure = &sshKeyResource{}
+	_ resource.Resource                   = &sshKeyResource{}
+	_ resource.ResourceWithConfigure      = &sshKeyResource{}
+	_ resource.ResourceWithValidateConfig = &sshKeyResource{}
+	_ resource.ResourceWithImportState    = &sshKeyResource{}
 )
 
+var sshKeyTypes = []string{common.SSHKeyTypeED25519, common.SSHKeyTypeRSA}
+
 // orderSSHKeyModel maps the resource schema data.
 type sshKeyResourceModel struct {
-	Metadata resourceMetadata `tfsdk:"metadata"`
-	Spec     sshkeySpec       `tfsdk:"spec"`
+	Metadata      sshKeyResourceMetadata `tfsdk:"metadata"`
+	Spec          sshKeyResourceSpec     `tfsdk:"spec"`
+	AdoptExisting types.Bool             `tfsdk:"adopt_existing"`
+	Region        types.String           `tfsdk:"region"`
+}
+
+type sshKeyResourceMetadata struct {
+	ResourceId   types.String `tfsdk:"resourceid"`
+	Cloudaccount types.String `tfsdk:"cloudaccount"`
+	Name         types.String `tfsdk:"name"`
+	Description  types.String `tfsdk:"description"`
+	CreatedAt    types.String `tfsdk:"createdat"`
+}
+
+type sshKeyResourceSpec struct {
+	SSHPublicKey    types.String `tfsdk:"ssh_public_key"`
+	GenerateKeyType types.String `tfsdk:"generate_key_type"`
+	PrivateKey      types.String `tfsdk:"private_key"`
+	Fingerprint     types.String `tfsdk:"fingerprint"`
+	OwnerEmail      types.String `tfsdk:"owner_email"`
 }
 
 // NewOrderFilesystem is a helper function to simplify the provider implementation.
@@ -64,20 +92,39 @@ func (r *sshKeyResource) Metadata(_ context.Context, req resource.MetadataReques
 func (r *sshKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"adopt_existing": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Adopt an existing key with the same name instead of creating a new one. The public key of the existing key must match ssh_public_key when it is set.",
+			},
+			"region": regionAttribute(),
 			"metadata": schema.SingleNestedAttribute{
 				Required: true,
 				Attributes: map[string]schema.Attribute{
 					"resourceid": schema.StringAttribute{
 						Computed: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
-					"cloudaccount": schema.StringAttribute{
-						Computed: true,
-					},
+					"cloudaccount": cloudaccountAttribute(),
 					"name": schema.StringAttribute{
-						Required: true,
+						Required:    true,
+						Description: "Name of the key. Changing this forces a new key.",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"description": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString(""),
+						Description: "Description of the key, updated in place.",
 					},
 					"createdat": schema.StringAttribute{
 						Computed: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
 				},
 			},
@@ -85,11 +132,42 @@ func (r *sshKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Required: true,
 				Attributes: map[string]schema.Attribute{
 					"ssh_public_key": schema.StringAttribute{
-						Required: true,
+						Optional:    true,
+						Computed:    true,
+						Description: "Public key in authorized_keys format. Exactly one of ssh_public_key or generate_key_type must be set. Changing this forces a new key.",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"generate_key_type": schema.StringAttribute{
+						Optional:    true,
+						Description: "Generate a keypair of this type, ed25519 or rsa, instead of uploading ssh_public_key. Changing this forces a new key.",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"private_key": schema.StringAttribute{
+						Computed:    true,
+						Sensitive:   true,
+						Description: "Private key in OpenSSH format, set when the keypair is generated.",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"fingerprint": schema.StringAttribute{
+						Computed:    true,
+						Description: "SHA256 fingerprint of the public key.",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
 					"owner_email": schema.StringAttribute{
 						Computed: true,
 						Optional: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
 				},
 			},
@@ -97,6 +175,35 @@ func (r *sshKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 	}
 }
 
+// ValidateConfig checks that the key is either uploaded or generated and that an uploaded key parses.
+func (r *sshKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var spec *sshKeyResourceSpec
+
+	diags := req.Config.GetAttribute(ctx, path.Root("spec"), &spec)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || spec == nil {
+		return
+	}
+
+	if spec.SSHPublicKey.IsUnknown() || spec.GenerateKeyType.IsUnknown() {
+		return
+	}
+	if spec.SSHPublicKey.IsNull() == spec.GenerateKeyType.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("spec"), "Invalid SSH key",
+			"Exactly one of ssh_public_key or generate_key_type must be set")
+		return
+	}
+	if !spec.GenerateKeyType.IsNull() && !slices.Contains(sshKeyTypes, spec.GenerateKeyType.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("spec").AtName("generate_key_type"), "Invalid SSH key type",
+			fmt.Sprintf("generate_key_type must be one of %v, got %q", sshKeyTypes, spec.GenerateKeyType.ValueString()))
+	}
+	if !spec.SSHPublicKey.IsNull() {
+		if _, err := common.SSHKeyFingerprint(spec.SSHPublicKey.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("spec").AtName("ssh_public_key"), "Invalid SSH public key", err.Error())
+		}
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *sshKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -108,20 +215,60 @@ func (r *sshKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	inArg := itacservices.SSHKeyCreateRequest{
-		Metadata: struct {
-			Name string "json:\"name\""
-		}{
-			Name: plan.Metadata.Name.ValueString(),
-		},
-		Spec: struct {
-			SSHPublicKey string "json:\"sshPublicKey\""
-		}{
-			SSHPublicKey: plan.Spec.SSHPublicKey.ValueString(),
-		},
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Metadata.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	plan.Region = types.StringValue(*client.Region)
+
+	plan.Spec.PrivateKey = types.StringNull()
+
+	existing, err := adoptExisting(ctx, plan.AdoptExisting, plan.Metadata.Name.ValueString(), client.GetSSHKeyByName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating order",
+			"Could not look up existing sshkey "+plan.Metadata.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if existing != nil {
+		tflog.Info(ctx, "adopting existing sshkey", map[string]any{"ID": existing.Metadata.ResourceId})
+		adoptSSHKey(ctx, client, &plan, existing, resp)
+		return
+	}
+
+	if !plan.Spec.GenerateKeyType.IsNull() {
+		tflog.Info(ctx, "generating sshkey pair", map[string]any{"type": plan.Spec.GenerateKeyType.ValueString()})
+		publicKey, privateKey, err := common.GenerateSSHKeyPair(plan.Spec.GenerateKeyType.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error generating sshkey",
+				"Could not generate sshkey pair: "+err.Error(),
+			)
+			return
+		}
+		plan.Spec.SSHPublicKey = types.StringValue(publicKey)
+		plan.Spec.PrivateKey = types.StringValue(privateKey)
+	}
+
+	fingerprint, err := common.SSHKeyFingerprint(plan.Spec.SSHPublicKey.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid SSH public key",
+			"Could not compute sshkey fingerprint: "+err.Error(),
+		)
+		return
+	}
+	plan.Spec.Fingerprint = types.StringValue(fingerprint)
+
+	inArg := itacservices.SSHKeyCreateRequest{}
+	inArg.Metadata.Name = plan.Metadata.Name.ValueString()
+	inArg.Metadata.Description = plan.Metadata.Description.ValueString()
+	inArg.Spec.SSHPublicKey = plan.Spec.SSHPublicKey.ValueString()
+
 	tflog.Info(ctx, "making a call to IDC Service for create sshkey")
-	sshkeyCreateResp, err := r.client.CreateSSHkey(ctx, &inArg)
+	sshkeyCreateResp, err := client.CreateSSHkey(ctx, &inArg)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating order",
@@ -145,6 +292,51 @@ func (r *sshKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 
 }
 
+// adoptSSHKey sets the state from an existing key. A configured public key must be the same
+// key, a generated keypair cannot be recovered and leaves private_key unset.
+func adoptSSHKey(ctx context.Context, client *itacservices.IDCServicesClient, plan *sshKeyResourceModel, existing *itacservices.SSHKey, resp *resource.CreateResponse) {
+	fingerprint, err := common.SSHKeyFingerprint(existing.Spec.SSHPublicKey)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error adopting sshkey",
+			"Could not parse public key of existing sshkey "+existing.Metadata.ResourceId+": "+err.Error(),
+		)
+		return
+	}
+	if !plan.Spec.SSHPublicKey.IsUnknown() {
+		if planFingerprint, err := common.SSHKeyFingerprint(plan.Spec.SSHPublicKey.ValueString()); err != nil || planFingerprint != fingerprint {
+			resp.Diagnostics.AddError(
+				"Error adopting sshkey",
+				"Existing sshkey "+existing.Metadata.Name+" has a different public key than ssh_public_key",
+			)
+			return
+		}
+	} else {
+		plan.Spec.SSHPublicKey = types.StringValue(existing.Spec.SSHPublicKey)
+	}
+
+	if !plan.Metadata.Description.IsUnknown() && plan.Metadata.Description.ValueString() != existing.Metadata.Description {
+		inArg := itacservices.SSHKeyUpdateRequest{}
+		inArg.Metadata.Description = plan.Metadata.Description.ValueString()
+		if err := client.UpdateSSHKey(ctx, existing.Metadata.ResourceId, &inArg); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating IDC SSHKey resource",
+				"Could not update IDC SSHKey resource ID "+existing.Metadata.ResourceId+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// the API does not report when the key was created, as on import
+	plan.Metadata.CreatedAt = types.StringNull()
+	plan.Metadata.ResourceId = types.StringValue(existing.Metadata.ResourceId)
+	plan.Metadata.Cloudaccount = types.StringValue(existing.Metadata.Cloudaccount)
+	plan.Spec.Fingerprint = types.StringValue(fingerprint)
+	plan.Spec.OwnerEmail = types.StringValue(existing.Spec.OwnerEmail)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (r *sshKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Get current state
@@ -155,8 +347,20 @@ func (r *sshKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Metadata.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Region = types.StringValue(*client.Region)
+
 	// Get refreshed order value from IDC Service
-	sshkey, err := r.client.GetSSHKeyByResourceId(ctx, state.Metadata.ResourceId.ValueString())
+	sshkey, err := client.GetSSHKeyByResourceId(ctx, state.Metadata.ResourceId.ValueString())
+	if common.IsNotFound(err) {
+		tflog.Warn(ctx, "sshkey not found, removing from state", map[string]any{"id": state.Metadata.ResourceId.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading IDC SSHKey resource",
@@ -165,15 +369,25 @@ func (r *sshKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	state.Metadata = resourceMetadata{
-		ResourceId:   types.StringValue(sshkey.Metadata.ResourceId),
-		Cloudaccount: types.StringValue(sshkey.Metadata.Cloudaccount),
-		Name:         types.StringValue(sshkey.Metadata.Name),
+	state.Metadata.ResourceId = types.StringValue(sshkey.Metadata.ResourceId)
+	state.Metadata.Cloudaccount = types.StringValue(sshkey.Metadata.Cloudaccount)
+	state.Metadata.Name = types.StringValue(sshkey.Metadata.Name)
+	state.Metadata.Description = types.StringValue(sshkey.Metadata.Description)
+	state.Spec.OwnerEmail = types.StringValue(sshkey.Spec.OwnerEmail)
+
+	// the service may normalize the key, only a different key is a change
+	fingerprint, err := common.SSHKeyFingerprint(sshkey.Spec.SSHPublicKey)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading IDC SSHKey resource",
+			"Could not parse public key of IDC SSHKey resource ID "+state.Metadata.ResourceId.ValueString()+": "+err.Error(),
+		)
+		return
 	}
-	state.Spec = sshkeySpec{
-		SSHPublicKey: types.StringValue(sshkey.Spec.SSHPublicKey),
-		OwnerEmail:   types.StringValue(sshkey.Spec.OwnerEmail),
+	if stateFingerprint, err := common.SSHKeyFingerprint(state.Spec.SSHPublicKey.ValueString()); err != nil || stateFingerprint != fingerprint {
+		state.Spec.SSHPublicKey = types.StringValue(sshkey.Spec.SSHPublicKey)
 	}
+	state.Spec.Fingerprint = types.StringValue(fingerprint)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -185,7 +399,102 @@ func (r *sshKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
+// All other attributes force a new key, only the description is updated in place.
 func (r *sshKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state sshKeyResourceModel
+
+	// Retrieve the desired configuration from the plan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := regionalClient(ctx, r.client, plan.Region, plan.Metadata.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Region = types.StringValue(*client.Region)
+
+	// Retrieve the current state
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Metadata.Description.Equal(state.Metadata.Description) {
+		inArg := itacservices.SSHKeyUpdateRequest{}
+		inArg.Metadata.Description = plan.Metadata.Description.ValueString()
+
+		tflog.Info(ctx, "making a call to IDC Service for update sshkey", map[string]any{"ID": state.Metadata.ResourceId.ValueString()})
+		if err := client.UpdateSSHKey(ctx, state.Metadata.ResourceId.ValueString(), &inArg); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating IDC SSHKey resource",
+				"Could not update IDC SSHKey resource ID "+state.Metadata.ResourceId.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// UseStateForUnknown keeps a null private key unknown, carry it over from state
+	plan.Spec.PrivateKey = state.Spec.PrivateKey
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ImportState resolves the key by resource ID or name. The private key of a generated
+// keypair is not stored by the service and cannot be imported.
+func (r *sshKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scope, diags := resolveImportScope(ctx, r.client, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := resolveImportIDOrName(ctx, "sshkey", scope.id,
+		func(ctx context.Context, id string) error {
+			_, err := scope.client.GetSSHKeyByResourceId(ctx, id)
+			return err
+		},
+		func(ctx context.Context, name string) (string, error) {
+			key, err := scope.client.GetSSHKeyByName(ctx, name)
+			if err != nil {
+				return "", err
+			}
+			return key.Metadata.ResourceId, nil
+		})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import IDC SSHKey resource", err.Error())
+		return
+	}
+
+	// metadata and spec are nested, so seed the whole model and let Read fill it in
+	state := sshKeyResourceModel{
+		Metadata: sshKeyResourceMetadata{
+			ResourceId:   types.StringValue(id),
+			Cloudaccount: scope.cloudaccount(),
+			Name:         types.StringNull(),
+			Description:  types.StringNull(),
+			CreatedAt:    types.StringNull(),
+		},
+		Spec: sshKeyResourceSpec{
+			SSHPublicKey:    types.StringNull(),
+			GenerateKeyType: types.StringNull(),
+			PrivateKey:      types.StringNull(),
+			Fingerprint:     types.StringNull(),
+			OwnerEmail:      types.StringNull(),
+		},
+		AdoptExisting: types.BoolNull(),
+		Region:        scope.region(),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -198,8 +507,14 @@ func (r *sshKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	client, diags := regionalClient(ctx, r.client, state.Region, state.Metadata.Cloudaccount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the order from IDC Services
-	err := r.client.DeleteSSHKeyByResourceId(ctx, state.Metadata.ResourceId.ValueString())
+	err := client.DeleteSSHKeyByResourceId(ctx, state.Metadata.ResourceId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting IDC SSHKey resource",
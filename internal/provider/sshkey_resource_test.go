@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"testing"
+
+	"terraform-provider-intelcloud/pkg/fakeitac"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testAccPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHd5Y2Ffn6ZzX1J6m5n9m0Rr3QvS2m5y4gC7bD3uVx1W test@example.com"
+
+func TestAccSSHKeyResource(t *testing.T) {
+	server, providerConfig := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(server, "sshpublickeys"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "intelcloud_sshkey" "test" {
+  metadata = {
+    name        = "tf-acc-key"
+    description = "first"
+  }
+  spec = {
+    ssh_public_key = "` + testAccPublicKey + `"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("intelcloud_sshkey.test", "metadata.resourceid"),
+					resource.TestCheckResourceAttr("intelcloud_sshkey.test", "metadata.cloudaccount", server.CloudAccount),
+					resource.TestCheckResourceAttr("intelcloud_sshkey.test", "metadata.description", "first"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "intelcloud_sshkey" "test" {
+  metadata = {
+    name        = "tf-acc-key"
+    description = "second"
+  }
+  spec = {
+    ssh_public_key = "` + testAccPublicKey + `"
+  }
+}
+`,
+				Check: resource.TestCheckResourceAttr("intelcloud_sshkey.test", "metadata.description", "second"),
+			},
+			{
+				ResourceName:                         "intelcloud_sshkey.test",
+				ImportState:                          true,
+				ImportStateId:                        "tf-acc-key",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "metadata.resourceid",
+				ImportStateVerifyIgnore:              []string{"adopt_existing", "metadata.createdat", "spec.generate_key_type", "spec.private_key"},
+			},
+		},
+	})
+}
+
+func TestAccSSHKeyResource_Vanished(t *testing.T) {
+	server, providerConfig := testAccFakeServer(t)
+	config := providerConfig + `
+resource "intelcloud_sshkey" "test" {
+  metadata = {
+    name = "tf-acc-key"
+  }
+  spec = {
+    ssh_public_key = "` + testAccPublicKey + `"
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(server, "sshpublickeys"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				// a key deleted outside Terraform is dropped from state and planned again
+				PreConfig: func() {
+					server.InjectFault(fakeitac.Fault{Method: "GET", Path: "/sshpublickeys/id/", Status: 404, Message: "sshkey not found"})
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: server.ClearFaults,
+				Config:    config,
+			},
+		},
+	})
+}
@@ -24,6 +24,14 @@ const (
 	IKSLoadBalancerResourceName    = "iksloadbalancer"
 	IKSLoadBalancerResourceTimeout = "30m"
 
+	//Load Balancer defaults
+	LoadBalancerResourceName    = "loadbalancer"
+	LoadBalancerResourceTimeout = "15m"
+
+	//Firewall rule defaults
+	FirewallRuleResourceName    = "firewallrule"
+	FirewallRuleResourceTimeout = "5m"
+
 	//Filesystem defaults
 	FilesystemResourceName    = "filesystem"
 	FileSystemResourceTimeout = "5m"
@@ -38,6 +46,8 @@ var DefaultTimeouts = map[string]string{
 	IKSNodegroupResourceName:    IKSNodegroupResourceTimeout,
 	IKSClusterResourceName:      IKSClusterResourceTimeout,
 	IKSLoadBalancerResourceName: IKSLoadBalancerResourceTimeout,
+	LoadBalancerResourceName:    LoadBalancerResourceTimeout,
+	FirewallRuleResourceName:    FirewallRuleResourceTimeout,
 	FilesystemResourceName:      FileSystemResourceTimeout,
 	ObjectStorageResourceName:   ObjectstorageResourceTimeout,
 }
@@ -1,8 +1,19 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"strings"
 
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -22,3 +33,91 @@ func convertTFStringsToGoStrings(tfStrings []types.String) []string {
 	}
 	return goStrings
 }
+
+// equalStringSets reports whether both lists hold the same values, ignoring order.
+func equalStringSets(a, b []types.String) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := map[string]int{}
+	for _, v := range a {
+		counts[v.ValueString()]++
+	}
+	for _, v := range b {
+		counts[v.ValueString()]--
+		if counts[v.ValueString()] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// stringListValue converts a list of Go strings to a Terraform list value.
+func stringListValue(values []string) types.List {
+	elems := []attr.Value{}
+	for _, v := range values {
+		elems = append(elems, types.StringValue(v))
+	}
+	return types.ListValueMust(types.StringType, elems)
+}
+
+// stringValueOrNull maps an empty API value to null, so optional attributes that were
+// never set read back unchanged.
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+// adoptExisting looks up an existing resource with the given name when adopt is set. It
+// returns nil when adoption is off or no resource has that name.
+func adoptExisting[T any](ctx context.Context, adopt types.Bool, name string, byName func(context.Context, string) (*T, error)) (*T, error) {
+	if !adopt.ValueBool() {
+		return nil, nil
+	}
+	existing, err := byName(ctx, name)
+	if errors.Is(err, common.ErrNotFound) {
+		return nil, nil
+	}
+	return existing, err
+}
+
+// regionAttribute is the schema of the region a resource is managed in, which defaults to
+// the provider region.
+func regionAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Region of the resource, one of the regions of the region catalog. Defaults to the provider region. Changing this forces a new resource.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplaceIfConfigured(),
+		},
+	}
+}
+
+// cloudaccountAttribute is the schema of the cloud account a resource is managed in, which
+// defaults to the provider cloud account.
+func cloudaccountAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Cloud account of the resource. Defaults to the provider cloud account. Changing this forces a new resource.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplaceIfConfigured(),
+		},
+	}
+}
+
+// regionalClient returns the client for the region and cloud account of a resource, the
+// provider client when neither is set. Callers pass it on to the helpers they call.
+func regionalClient(ctx context.Context, client *itacservices.IDCServicesClient, region, cloudaccount types.String) (*itacservices.IDCServicesClient, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	regional, err := client.ForRegion(ctx, region.ValueString(), cloudaccount.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("region"), "Unable to Create ITAC API Client", err.Error())
+	}
+	return regional, diags
+}
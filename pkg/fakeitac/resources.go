@@ -0,0 +1,218 @@
+package fakeitac
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// kind describes how one collection of the cloud account API behaves.
+type kind struct {
+	// path is the collection path below /v1/cloudaccounts/<account>/.
+	path string
+	noun string
+	// listKey is the field holding the items of a list response.
+	listKey  string
+	idPath   []string
+	namePath []string
+	// bareID serves single resources at <path>/<id> instead of <path>/id/<id>.
+	bareID bool
+
+	// phase is the field holding the lifecycle phase, nil for resources that are
+	// ready as soon as they are created.
+	phase                            []string
+	pending, ready, failed, deleting string
+
+	// prepare fills in the fields the service computes for a new resource.
+	prepare func(doc map[string]any, n int)
+	// actions serve the sub resources of a single resource, keyed by sub path.
+	actions map[string]func(obj *object, method string, body map[string]any) any
+}
+
+var metadataID = []string{"metadata", "resourceId"}
+var metadataName = []string{"metadata", "name"}
+var statusPhase = []string{"status", "phase"}
+
+var kinds = []*kind{
+	{
+		path:     "instances",
+		noun:     "instance",
+		listKey:  "items",
+		idPath:   metadataID,
+		namePath: metadataName,
+		phase:    statusPhase,
+		pending:  "Provisioning",
+		ready:    "Ready",
+		failed:   "Failed",
+		deleting: "Deleting",
+		prepare:  prepareInstance,
+	},
+	{
+		path:     "vnets",
+		noun:     "vnet",
+		listKey:  "items",
+		idPath:   metadataID,
+		namePath: metadataName,
+	},
+	{
+		path:     "sshpublickeys",
+		noun:     "sshkey",
+		listKey:  "items",
+		idPath:   metadataID,
+		namePath: metadataName,
+	},
+	{
+		path:     "filesystems",
+		noun:     "filesystem",
+		listKey:  "items",
+		idPath:   metadataID,
+		namePath: metadataName,
+		phase:    statusPhase,
+		pending:  "FSProvisioning",
+		ready:    "FSReady",
+		failed:   "FSFailed",
+		deleting: "FSDeleting",
+		prepare:  prepareFilesystem,
+		actions: map[string]func(*object, string, map[string]any) any{
+			"user": func(obj *object, _ string, _ map[string]any) any {
+				return map[string]any{
+					"user":     getString(obj.doc, []string{"status", "mount", "username"}),
+					"password": "fake-password",
+				}
+			},
+		},
+	},
+	{
+		path:     "objects/buckets",
+		noun:     "bucket",
+		listKey:  "buckets",
+		idPath:   metadataID,
+		namePath: metadataName,
+		phase:    statusPhase,
+		pending:  "BucketProvisioning",
+		ready:    "BucketReady",
+		failed:   "BucketFailed",
+		deleting: "BucketDeleting",
+		prepare:  prepareBucket,
+		actions: map[string]func(*object, string, map[string]any) any{
+			"securitygroup": func(obj *object, method string, body map[string]any) any {
+				if method == http.MethodPut {
+					setPath(obj.doc, []string{"status", "securityGroup"}, body)
+				}
+				return obj.doc
+			},
+		},
+	},
+	{
+		path:     "objects/users",
+		noun:     "bucket user",
+		listKey:  "users",
+		idPath:   []string{"metadata", "userId"},
+		namePath: metadataName,
+		prepare:  prepareBucketUser,
+	},
+	{
+		path:     "iks/clusters",
+		noun:     "iks cluster",
+		listKey:  "clusters",
+		idPath:   []string{"uuid"},
+		namePath: []string{"name"},
+		bareID:   true,
+		phase:    []string{"clusterstate"},
+		pending:  "Pending",
+		ready:    "Active",
+		failed:   "Failed",
+		deleting: "Deleting",
+		prepare:  prepareIKSCluster,
+	},
+}
+
+var kindsByPath = func() map[string]*kind {
+	m := map[string]*kind{}
+	for _, k := range kinds {
+		m[k.path] = k
+	}
+	return m
+}()
+
+func prepareInstance(doc map[string]any, n int) {
+	vnet := ""
+	if spec, ok := doc["spec"].(map[string]any); ok {
+		// the create request names the vnet of an interface vNet, responses vnet
+		if nics, ok := spec["interfaces"].([]any); ok {
+			for _, nic := range nics {
+				if m, ok := nic.(map[string]any); ok {
+					if v, ok := m["vNet"].(string); ok {
+						m["vnet"] = v
+						delete(m, "vNet")
+						vnet = v
+					}
+				}
+			}
+		}
+	}
+
+	name := getString(doc, metadataName)
+	setPath(doc, []string{"status", "interfaces"}, []any{
+		map[string]any{
+			"name":         "eth0",
+			"addresses":    []any{fmt.Sprintf("10.0.0.%d", n%250+2)},
+			"dnsName":      name + ".fake.internal",
+			"gateway":      "10.0.0.1",
+			"prefixLength": 24,
+			"subnet":       "10.0.0.0",
+			"vNet":         vnet,
+		},
+	})
+	setPath(doc, []string{"status", "userName"}, "ubuntu")
+	setPath(doc, []string{"status", "sshProxy"}, map[string]any{
+		"proxyAddress": "proxy.fake.internal",
+		"proxyPort":    22,
+		"proxyUser":    "guest",
+	})
+}
+
+func prepareFilesystem(doc map[string]any, n int) {
+	setPath(doc, []string{"status", "mount"}, map[string]any{
+		"clusterAddr":    "fs.fake.internal",
+		"clusterVersion": "1.0",
+		"namespace":      fmt.Sprintf("ns-%d", n),
+		"username":       fmt.Sprintf("user-%d", n),
+		"filesystemName": getString(doc, metadataName),
+	})
+}
+
+func prepareBucket(doc map[string]any, n int) {
+	setPath(doc, []string{"status", "cluster"}, map[string]any{
+		"accessEndpoint": "https://objects.fake.internal",
+		"clusterId":      "fake-cluster",
+	})
+	setPath(doc, []string{"status", "securityGroup"}, map[string]any{
+		"networkFilterAllow": []any{},
+	})
+}
+
+func prepareBucketUser(doc map[string]any, n int) {
+	setPath(doc, []string{"status", "phase"}, "ObjectUserReady")
+	setPath(doc, []string{"status", "principal", "credentials"}, map[string]any{
+		"accessKey": fmt.Sprintf("fake-access-key-%d", n),
+		"secretKey": fmt.Sprintf("fake-secret-key-%d", n),
+	})
+}
+
+func prepareIKSCluster(doc map[string]any, n int) {
+	// the create request names the version k8sversionname, responses k8sversion
+	doc["k8sversion"] = doc["k8sversionname"]
+	delete(doc, "k8sversionname")
+	doc["createddate"] = time.Now().UTC().Format(time.RFC3339)
+	doc["network"] = map[string]any{
+		"enableloadbalancer": false,
+		"servicecidr":        "10.96.0.0/12",
+		"clustercidr":        "100.68.0.0/16",
+		"clusterdns":         "10.96.0.10",
+	}
+	doc["nodegroups"] = []any{}
+	doc["storages"] = []any{}
+	doc["vips"] = []any{}
+	doc["upgradeavailable"] = false
+}
@@ -0,0 +1,526 @@
+// Package fakeitac is an in-memory stand-in for the ITAC token and compute APIs,
+// used to run the provider acceptance tests without cloud credentials.
+//
+// Resources created through the fake move through the same phases as the real
+// service: they are provisioning for a few reads before becoming ready, and
+// deleting for a few reads before they disappear. Faults can be injected to make
+// matching requests fail or to make new resources end up failed.
+package fakeitac
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultCloudAccount is the cloud account served by a new Server.
+	DefaultCloudAccount = "123456789012"
+	// DefaultClientID and DefaultClientSecret are the credentials accepted by a new Server.
+	DefaultClientID     = "fake-client-id"
+	DefaultClientSecret = "fake-client-secret"
+
+	// AccessToken is the token issued for the credentials and accepted by the API.
+	AccessToken = "fake-access-token"
+)
+
+// servers numbers the servers started, so that resource IDs are unique across the servers
+// of a test like the UUIDs of the real service.
+var servers atomic.Int64
+
+// Server is a running fake ITAC API. Both the token service and the compute API are
+// served from URL.
+type Server struct {
+	*httptest.Server
+
+	// CloudAccount, ClientID and ClientSecret are the account and credentials the
+	// server accepts. They may be changed before the first request.
+	CloudAccount string
+	ClientID     string
+	ClientSecret string
+	// TransitionReads is how many reads a resource stays in a transitional phase
+	// before moving on, so callers that poll see at least one pending phase.
+	TransitionReads int
+
+	mu      sync.Mutex
+	objects map[string][]*object
+	faults  []*Fault
+	serial  int64
+	nextID  int
+	errors  int
+}
+
+// Fault changes how the server answers requests matching Method and Path.
+type Fault struct {
+	// Method matches the request method, empty matches any method.
+	Method string
+	// Path matches requests whose path contains it, e.g. "/filesystems".
+	Path string
+	// Status is returned with Message instead of serving the request.
+	Status  int
+	Message string
+	// FailProvisioning serves a create request but moves the new resource to its
+	// failed phase instead of the ready one, with Message as its status message.
+	// Ignored when Status is set.
+	FailProvisioning bool
+	// Count is how many matching requests the fault applies to, 0 for all of them.
+	Count int
+
+	hits int
+}
+
+type object struct {
+	kind  *kind
+	doc   map[string]any
+	next  string // phase reached once reads runs out, empty when stable
+	reads int
+	// message is the status message reported once the resource reaches next.
+	message string
+}
+
+// NewServer starts a fake ITAC API. The server is closed when the test ends.
+func NewServer(t interface{ Cleanup(func()) }) *Server {
+	s := &Server{
+		CloudAccount:    DefaultCloudAccount,
+		ClientID:        DefaultClientID,
+		ClientSecret:    DefaultClientSecret,
+		TransitionReads: 1,
+		objects:         map[string][]*object{},
+		serial:          servers.Add(1),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// InjectFault registers a fault for the requests it matches.
+func (s *Server) InjectFault(f Fault) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fault := f
+	s.faults = append(s.faults, &fault)
+	return &fault
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Seed stores doc as an existing, ready resource of the collection, e.g. "vnets" or
+// "objects/buckets", and returns its ID.
+func (s *Server) Seed(collection string, doc map[string]any) (string, error) {
+	k, ok := kindsByPath[collection]
+	if !ok {
+		return "", fmt.Errorf("unknown collection %q", collection)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj := s.create(k, doc)
+	if k.phase != nil {
+		setPath(obj.doc, k.phase, k.ready)
+		obj.next = ""
+	}
+	return getString(obj.doc, k.idPath), nil
+}
+
+// Get returns a copy of the resource with the given ID, and whether it exists.
+func (s *Server) Get(collection, id string) (map[string]any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, obj := range s.objects[collection] {
+		if getString(obj.doc, obj.kind.idPath) == id {
+			return clone(obj.doc), true
+		}
+	}
+	return nil, false
+}
+
+// Count returns the number of resources in the collection that are not being deleted.
+func (s *Server) Count(collection string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, obj := range s.objects[collection] {
+		if obj.kind.phase == nil || getString(obj.doc, obj.kind.phase) != obj.kind.deleting {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fault := s.matchFault(r)
+	if fault != nil && fault.Status != 0 {
+		s.writeError(w, fault.Status, fault.Message)
+		return
+	}
+
+	if r.URL.Path == "/oauth2/token" {
+		s.serveToken(w, r)
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+AccessToken {
+		s.writeError(w, http.StatusUnauthorized, "invalid or missing access token")
+		return
+	}
+
+	prefix := "/v1/cloudaccounts/" + s.CloudAccount + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		s.writeError(w, http.StatusNotFound, "no route for "+r.URL.Path)
+		return
+	}
+	rest := strings.TrimPrefix(r.URL.Path, prefix)
+
+	for _, k := range kinds {
+		if rest != k.path && !strings.HasPrefix(rest, k.path+"/") {
+			continue
+		}
+		s.serveKind(w, r, k, strings.TrimPrefix(strings.TrimPrefix(rest, k.path), "/"), fault)
+		return
+	}
+	s.writeError(w, http.StatusNotFound, "no route for "+r.URL.Path)
+}
+
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for _, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.Contains(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Count > 0 && f.hits >= f.Count {
+			continue
+		}
+		f.hits++
+		return f
+	}
+	return nil
+}
+
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeError(w, http.StatusMethodNotAllowed, "token requests must be POST")
+		return
+	}
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != s.ClientID || secret != s.ClientSecret {
+		s.writeError(w, http.StatusUnauthorized, "invalid client credentials")
+		return
+	}
+	writeJSON(w, map[string]any{
+		"access_token": AccessToken,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+// serveKind serves the collection itself when sub is empty, and otherwise a single
+// resource addressed as id/<id>, name/<name> or, for IKS clusters, <uuid>.
+func (s *Server) serveKind(w http.ResponseWriter, r *http.Request, k *kind, sub string, fault *Fault) {
+	if sub == "" {
+		switch r.Method {
+		case http.MethodGet:
+			s.serveList(w, r, k)
+		case http.MethodPost:
+			s.serveCreate(w, r, k, fault)
+		default:
+			s.writeError(w, http.StatusMethodNotAllowed, r.Method+" is not supported on "+k.path)
+		}
+		return
+	}
+
+	parts := strings.Split(sub, "/")
+	lookup := k.idPath
+	if parts[0] == "id" || parts[0] == "name" {
+		if len(parts) < 2 {
+			s.writeError(w, http.StatusNotFound, "no route for "+r.URL.Path)
+			return
+		}
+		if parts[0] == "name" {
+			lookup = k.namePath
+		}
+		parts = parts[1:]
+	} else if !k.bareID {
+		s.writeError(w, http.StatusNotFound, "no route for "+r.URL.Path)
+		return
+	}
+
+	obj := s.find(k, lookup, parts[0])
+	if obj == nil {
+		s.writeError(w, http.StatusNotFound, fmt.Sprintf("%s %q not found", k.noun, parts[0]))
+		return
+	}
+
+	if len(parts) > 1 {
+		action, ok := k.actions[strings.Join(parts[1:], "/")]
+		if !ok {
+			s.writeError(w, http.StatusNotFound, "no route for "+r.URL.Path)
+			return
+		}
+		body, err := readBody(r)
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeJSON(w, action(obj, r.Method, body))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.advance(obj)
+		if s.find(k, k.idPath, getString(obj.doc, k.idPath)) == nil {
+			s.writeError(w, http.StatusNotFound, fmt.Sprintf("%s %q not found", k.noun, parts[0]))
+			return
+		}
+		writeJSON(w, obj.doc)
+	case http.MethodPut:
+		body, err := readBody(r)
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		merge(obj.doc, body)
+		writeJSON(w, obj.doc)
+	case http.MethodDelete:
+		s.delete(obj)
+		writeJSON(w, map[string]any{})
+	default:
+		s.writeError(w, http.StatusMethodNotAllowed, r.Method+" is not supported on "+k.path)
+	}
+}
+
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, k *kind) {
+	for _, obj := range append([]*object{}, s.objects[k.path]...) {
+		s.advance(obj)
+	}
+
+	items := []map[string]any{}
+	for _, obj := range s.objects[k.path] {
+		items = append(items, obj.doc)
+	}
+
+	// page through the collection with the offset as continuation token
+	start, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+	start = min(max(start, 0), len(items))
+	end := len(items)
+	if size, _ := strconv.Atoi(r.URL.Query().Get("pageSize")); size > 0 {
+		end = min(start+size, len(items))
+	}
+
+	resp := map[string]any{k.listKey: items[start:end]}
+	if end < len(items) {
+		resp["nextPageToken"] = strconv.Itoa(end)
+	}
+	writeJSON(w, resp)
+}
+
+func (s *Server) serveCreate(w http.ResponseWriter, r *http.Request, k *kind, fault *Fault) {
+	body, err := readBody(r)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	name := getString(body, k.namePath)
+	if name == "" {
+		s.writeError(w, http.StatusBadRequest, k.noun+" name is required")
+		return
+	}
+	if s.find(k, k.namePath, name) != nil {
+		s.writeError(w, http.StatusConflict, fmt.Sprintf("%s %q already exists", k.noun, name))
+		return
+	}
+
+	obj := s.create(k, body)
+	if fault != nil && fault.FailProvisioning && k.phase != nil {
+		obj.next = k.failed
+		obj.message = fault.Message
+	}
+	writeJSON(w, obj.doc)
+}
+
+// create stores a new resource built from body. Callers hold s.mu.
+func (s *Server) create(k *kind, body map[string]any) *object {
+	s.nextID++
+	doc := clone(body)
+	setPath(doc, k.idPath, fmt.Sprintf("%08x-%04x-4000-8000-%012d", s.nextID, s.serial, s.nextID))
+	if k.idPath[0] == "metadata" {
+		setPath(doc, []string{"metadata", "cloudAccountId"}, s.CloudAccount)
+		setPath(doc, []string{"metadata", "creationTimestamp"}, time.Now().UTC().Format(time.RFC3339))
+	}
+	if k.prepare != nil {
+		k.prepare(doc, s.nextID)
+	}
+
+	obj := &object{kind: k, doc: doc}
+	if k.phase != nil {
+		setPath(doc, k.phase, k.pending)
+		obj.next = k.ready
+		obj.reads = s.TransitionReads
+	}
+	s.objects[k.path] = append(s.objects[k.path], obj)
+	return obj
+}
+
+// delete starts deleting obj. Resources without phases disappear at once.
+func (s *Server) delete(obj *object) {
+	k := obj.kind
+	if k.phase == nil {
+		s.remove(obj)
+		return
+	}
+	if getString(obj.doc, k.phase) == k.deleting {
+		return
+	}
+	setPath(obj.doc, k.phase, k.deleting)
+	obj.next = ""
+	obj.reads = s.TransitionReads
+}
+
+// advance counts a read of obj and moves it to its next phase once the reads run
+// out. Deleting resources are removed instead.
+func (s *Server) advance(obj *object) {
+	k := obj.kind
+	if k.phase == nil {
+		return
+	}
+	deleting := getString(obj.doc, k.phase) == k.deleting
+	if !deleting && obj.next == "" {
+		return
+	}
+	if obj.reads > 0 {
+		obj.reads--
+		return
+	}
+	if deleting {
+		s.remove(obj)
+		return
+	}
+	setPath(obj.doc, k.phase, obj.next)
+	if obj.message != "" && k.phase[0] == "status" {
+		setPath(obj.doc, []string{"status", "message"}, obj.message)
+	}
+	obj.next = ""
+}
+
+func (s *Server) remove(obj *object) {
+	list := s.objects[obj.kind.path]
+	for i := range list {
+		if list[i] == obj {
+			s.objects[obj.kind.path] = append(list[:i], list[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Server) find(k *kind, field []string, value string) *object {
+	for _, obj := range s.objects[k.path] {
+		if getString(obj.doc, field) == value {
+			return obj
+		}
+	}
+	return nil
+}
+
+func readBody(r *http.Request) (map[string]any, error) {
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]any{}
+	if len(raw) == 0 {
+		return body, nil
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, fmt.Errorf("invalid request body: %v", err)
+	}
+	return body, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError answers with an error body in the service format. Every error carries a
+// request ID, like the responses of the real service.
+func (s *Server) writeError(w http.ResponseWriter, status int, message string) {
+	s.errors++
+	w.Header().Set("X-Request-Id", fmt.Sprintf("fake-request-%d", s.errors))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"code":    status,
+		"message": message,
+		"details": []any{},
+	})
+}
+
+func getString(doc map[string]any, path []string) string {
+	var cur any = doc
+	for _, p := range path {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return ""
+		}
+		cur = m[p]
+	}
+	s, _ := cur.(string)
+	return s
+}
+
+func setPath(doc map[string]any, path []string, value any) {
+	cur := doc
+	for _, p := range path[:len(path)-1] {
+		next, ok := cur[p].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			cur[p] = next
+		}
+		cur = next
+	}
+	cur[path[len(path)-1]] = value
+}
+
+// merge applies an update body to doc, replacing everything but nested objects.
+func merge(doc, update map[string]any) {
+	for key, value := range update {
+		src, isMap := value.(map[string]any)
+		dst, ok := doc[key].(map[string]any)
+		if isMap && ok {
+			merge(dst, src)
+			continue
+		}
+		doc[key] = value
+	}
+}
+
+func clone(doc map[string]any) map[string]any {
+	raw, _ := json.Marshal(doc)
+	out := map[string]any{}
+	_ = json.Unmarshal(raw, &out)
+	return out
+}
@@ -9,7 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"os"
+	"sync"
 	"terraform-provider-intelcloud/pkg/itacservices/common"
 	"time"
 
@@ -25,6 +25,29 @@ type IDCServicesClient struct {
 	Clientsecret *string
 	ExpireAt     time.Time
 	APIClient    common.APIClient
+	// HTTPClient sends every request of the client, a shared default client when nil.
+	HTTPClient *http.Client
+	// Regions is the catalog the region of the client is looked up in, the built-in
+	// regions when nil.
+	Regions *common.RegionCatalog
+
+	tokenHost string
+	// credentialProcess is the command the credentials are taken from, empty when the
+	// client was given a client ID and secret.
+	credentialProcess string
+	tokenMu           sync.Mutex
+	// clients caches the clients ForRegion derives, shared by every client of the cache.
+	clients *clientCache
+}
+
+// clientCache holds the clients of the regions and cloud accounts used so far.
+type clientCache struct {
+	mu      sync.Mutex
+	clients map[clientKey]*IDCServicesClient
+}
+
+type clientKey struct {
+	region, cloudaccount string
 }
 
 var (
@@ -37,45 +60,103 @@ type TokenResponse struct {
 	ExpiresIn   int    `json:"expires_in"`
 }
 
-func NewClient(ctx context.Context, host, tokenSvc, cloudaccount, clientid, clientsecret, region *string) (*IDCServicesClient, error) {
-	os.Setenv("NO_PROXY", "")
-	os.Setenv("no_proxy", "")
+// tokenExpiryMargin is how long before it expires a token of a credential process is renewed,
+// so it does not expire while a request is in flight.
+const tokenExpiryMargin = time.Minute
+
+// NewClient fetches an access token and returns a client sending its requests through
+// httpClient, which it keeps for the life of the client.
+func NewClient(ctx context.Context, host, tokenSvc, cloudaccount, clientid, clientsecret, region *string, httpClient *http.Client) (*IDCServicesClient, error) {
+	client, err := newClient(host, tokenSvc, cloudaccount, region, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenResp, err := fetchToken(ctx, client.HTTPClient, *tokenSvc, *clientid, *clientsecret)
+	if err != nil {
+		return nil, err
+	}
+	client.Clientid = clientid
+	client.Clientsecret = clientsecret
+	client.Apitoken = &tokenResp.AccessToken
+	client.ExpireAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	return client, nil
+}
+
+// NewClientWithCredentialProcess returns a client taking its credentials from the output of
+// the credential process command, see common.RunCredentialProcess. The command is run again
+// whenever the access token is about to expire.
+func NewClientWithCredentialProcess(ctx context.Context, host, tokenSvc, cloudaccount, region *string, command string, httpClient *http.Client) (*IDCServicesClient, error) {
+	client, err := newClient(host, tokenSvc, cloudaccount, region, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	client.credentialProcess = command
+	if err := client.refreshToken(ctx); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
 
+// newClient returns a client without credentials, the only client of its cache.
+func newClient(host, tokenSvc, cloudaccount, region *string, httpClient *http.Client) (*IDCServicesClient, error) {
+	if httpClient == nil {
+		var err error
+		if httpClient, err = common.NewHTTPClient(common.TransportConfig{}); err != nil {
+			return nil, err
+		}
+	}
+
+	client := &IDCServicesClient{
+		Host:         host,
+		Cloudaccount: cloudaccount,
+		Region:       region,
+		APIClient:    common.NewAPIClient(httpClient),
+		HTTPClient:   httpClient,
+		tokenHost:    *tokenSvc,
+	}
+	client.clients = &clientCache{clients: map[clientKey]*IDCServicesClient{
+		{region: *region, cloudaccount: *cloudaccount}: client,
+	}}
+	return client, nil
+}
+
+// fetchToken logs in to the token service with a client ID and secret.
+func fetchToken(ctx context.Context, httpClient *http.Client, tokenSvc, clientid, clientsecret string) (*TokenResponse, error) {
 	params := struct {
 		Host string
 	}{
-		Host: *tokenSvc,
+		Host: tokenSvc,
 	}
 
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(getTokenURL, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the ITAC token url: %w", err)
 	}
 
 	data := url.Values{}
 	data.Set("grant_type", "client_credentials")
-	data.Set("client_id", *clientid)
+	data.Set("client_id", clientid)
 
-	req, err := http.NewRequest("POST", parsedURL, bytes.NewBufferString(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", parsedURL, bytes.NewBufferString(data.Encode()))
 	if err != nil {
-		return nil, fmt.Errorf("error creating ITAC Token request")
+		return nil, fmt.Errorf("error creating ITAC Token request: %w", err)
 	}
 
-	authStr := fmt.Sprintf("%s:%s", *clientid, *clientsecret)
+	authStr := fmt.Sprintf("%s:%s", clientid, clientsecret)
 	authEncoded := fmt.Sprintf("Basic %s", b64.StdEncoding.EncodeToString([]byte(authStr)))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Accept", "application/json")
 
 	req.Header.Set("Authorization", authEncoded)
-	client := &http.Client{Timeout: 60 * time.Second}
+	// the headers carry the client secret, log the url only
+	tflog.Info(ctx, "making api client request", map[string]interface{}{"url": parsedURL})
 
-	tflog.Info(ctx, "making api client request", map[string]interface{}{"request": req.Header, "url": parsedURL})
-
-	resp, err := client.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		tflog.Info(ctx, "error making api client request", map[string]interface{}{"error": err})
-		return nil, fmt.Errorf("error creating ITAC Token request")
+		return nil, fmt.Errorf("error creating ITAC Token request: %w", err)
 	}
 	defer resp.Body.Close()
 	body, _ := io.ReadAll(resp.Body)
@@ -83,23 +164,139 @@ func NewClient(ctx context.Context, host, tokenSvc, cloudaccount, clientid, clie
 	tokenResp := TokenResponse{}
 	if retcode != http.StatusOK {
 		tflog.Info(ctx, "error making api client request", map[string]interface{}{"retcode": retcode, "body": string(body)})
-		return nil, fmt.Errorf("error creating ITAC Token request")
+		return nil, fmt.Errorf("error creating ITAC Token request: %w", common.ResponseError(resp, body))
 	}
 
 	if err = json.Unmarshal(body, &tokenResp); err != nil {
 		tflog.Info(ctx, "error making api client request", map[string]interface{}{"error": err})
-		return nil, fmt.Errorf("error creating ITAC Token request")
+		return nil, fmt.Errorf("error parsing ITAC Token response: %w", err)
 	}
 
-	tflog.Info(ctx, "Token Response", map[string]interface{}{"token": tokenResp.AccessToken, "expires_in": tokenResp.ExpiresIn})
-	return &IDCServicesClient{
-		Host:         host,
-		Cloudaccount: cloudaccount,
-		Clientid:     clientid,
-		Clientsecret: clientsecret,
-		Region:       region,
-		Apitoken:     &tokenResp.AccessToken,
-		ExpireAt:     time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second),
-		APIClient:    common.NewAPIClient(),
-	}, nil
+	tflog.Info(ctx, "Token Response", map[string]interface{}{"expires_in": tokenResp.ExpiresIn})
+	return &tokenResp, nil
+}
+
+// refreshToken runs the credential process of the client and stores the access token it
+// prints, or the one the token service issues for the client ID and secret it prints. The
+// caller holds tokenMu or owns the client.
+func (client *IDCServicesClient) refreshToken(ctx context.Context) error {
+	creds, err := common.RunCredentialProcess(ctx, client.credentialProcess)
+	if err != nil {
+		return err
+	}
+
+	if creds.AccessToken != "" {
+		client.Apitoken = &creds.AccessToken
+		client.ExpireAt = creds.ExpiresAt
+		return nil
+	}
+
+	tokenResp, err := fetchToken(ctx, client.HTTPClient, client.tokenHost, creds.ClientID, creds.ClientSecret)
+	if err != nil {
+		return err
+	}
+	client.Clientid = &creds.ClientID
+	client.Clientsecret = &creds.ClientSecret
+	client.Apitoken = &tokenResp.AccessToken
+	client.ExpireAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	if !creds.ExpiresAt.IsZero() && creds.ExpiresAt.Before(client.ExpireAt) {
+		client.ExpireAt = creds.ExpiresAt
+	}
+	return nil
+}
+
+// accessToken returns the token requests are sent with. A client with a credential process
+// runs it again once the token is about to expire; when that fails the error is logged and
+// the old token is kept, so the request reports the failed authentication.
+func (client *IDCServicesClient) accessToken(ctx context.Context) string {
+	if client.credentialProcess == "" {
+		return *client.Apitoken
+	}
+
+	client.tokenMu.Lock()
+	defer client.tokenMu.Unlock()
+	if client.ExpireAt.IsZero() || time.Now().Add(tokenExpiryMargin).Before(client.ExpireAt) {
+		return *client.Apitoken
+	}
+
+	tflog.Info(ctx, "renewing expired access token with the credential process", map[string]any{"expired_at": client.ExpireAt})
+	if err := client.refreshToken(ctx); err != nil {
+		tflog.Error(ctx, "error renewing access token", map[string]any{"error": err.Error()})
+	}
+	return *client.Apitoken
+}
+
+// ForRegion returns the client for region and cloudaccount, logging in with the credentials
+// of client the first time a pair is used. An empty region or cloudaccount keeps the one of
+// client. The client of a region other than the client's own uses the endpoints of the region
+// catalog.
+func (client *IDCServicesClient) ForRegion(ctx context.Context, region, cloudaccount string) (*IDCServicesClient, error) {
+	if region == "" {
+		region = *client.Region
+	}
+	if cloudaccount == "" {
+		cloudaccount = *client.Cloudaccount
+	}
+	if region == *client.Region && cloudaccount == *client.Cloudaccount {
+		return client, nil
+	}
+	if client.clients == nil {
+		return nil, fmt.Errorf("error creating client for region %s and cloud account %s: client was not created with NewClient", region, cloudaccount)
+	}
+
+	client.clients.mu.Lock()
+	defer client.clients.mu.Unlock()
+
+	key := clientKey{region: region, cloudaccount: cloudaccount}
+	if derived, ok := client.clients.clients[key]; ok {
+		return derived, nil
+	}
+
+	host, tokenHost := *client.Host, client.tokenHost
+	if region != *client.Region {
+		regionInfo, err := client.regionInfo(region)
+		if err != nil {
+			return nil, err
+		}
+		host, tokenHost = regionInfo.APIEndpoint, regionInfo.AuthEndpoint
+	}
+
+	tflog.Info(ctx, "creating client", map[string]any{"region": region, "cloudaccount": cloudaccount})
+	var derived *IDCServicesClient
+	var err error
+	if client.credentialProcess != "" {
+		derived, err = NewClientWithCredentialProcess(ctx, &host, &tokenHost, &cloudaccount, &region, client.credentialProcess, client.HTTPClient)
+	} else {
+		derived, err = NewClient(ctx, &host, &tokenHost, &cloudaccount, client.Clientid, client.Clientsecret, &region, client.HTTPClient)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error creating client for region %s and cloud account %s: %w", region, cloudaccount, err)
+	}
+	derived.Regions = client.Regions
+	derived.clients = client.clients
+	client.clients.clients[key] = derived
+	return derived, nil
+}
+
+// RegionInfo returns the catalog entry of the client's region.
+func (client *IDCServicesClient) RegionInfo() (*common.Region, error) {
+	return client.regionInfo(*client.Region)
+}
+
+// DefaultAvailabilityZone returns the zone of the client's region used for resources that
+// do not name one.
+func (client *IDCServicesClient) DefaultAvailabilityZone() (string, error) {
+	region, err := client.RegionInfo()
+	if err != nil {
+		return "", err
+	}
+	return region.DefaultZone(), nil
+}
+
+func (client *IDCServicesClient) regionInfo(name string) (*common.Region, error) {
+	catalog := client.Regions
+	if catalog == nil {
+		catalog = common.DefaultRegionCatalog()
+	}
+	return catalog.Region(name)
 }
@@ -0,0 +1,59 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ProcessCredentials are the credentials printed by a credential process, either a client
+// ID and secret to log in with or an access token.
+type ProcessCredentials struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	AccessToken  string `json:"access_token"`
+	// ExpiresAt is when the credentials must be fetched again, zero when they do not expire.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// RunCredentialProcess runs command with the shell and parses the credentials it prints to
+// stdout as JSON, e.g. {"client_id": "...", "client_secret": "..."} or
+// {"access_token": "...", "expires_at": "2025-01-02T15:04:05Z"}. The stderr of the command
+// is only reported when it fails, so it never reaches the logs otherwise.
+func RunCredentialProcess(ctx context.Context, command string) (*ProcessCredentials, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("error running credential process: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("error running credential process: %w", err)
+	}
+
+	var creds ProcessCredentials
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return nil, fmt.Errorf("error parsing credential process output: %w", err)
+	}
+	hasClient := creds.ClientID != "" || creds.ClientSecret != ""
+	switch {
+	case hasClient && creds.AccessToken != "":
+		return nil, fmt.Errorf("credential process output must set either client_id and client_secret or access_token, not both")
+	case hasClient && (creds.ClientID == "" || creds.ClientSecret == ""):
+		return nil, fmt.Errorf("credential process output must set client_id and client_secret together")
+	case !hasClient && creds.AccessToken == "":
+		return nil, fmt.Errorf("credential process output must set client_id and client_secret, or access_token")
+	}
+	return &creds, nil
+}
@@ -0,0 +1,104 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrNotFound is wrapped by the error returned for a resource that does not exist.
+var ErrNotFound = errors.New("Not Found")
+
+// requestIDHeader is the response header carrying the ID support needs to trace a request.
+const requestIDHeader = "X-Request-Id"
+
+// APIError is the error returned for a response of the ITAC API that is not a success.
+type APIError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int `json:"-"`
+	// Code is the backend error code.
+	Code      int           `json:"code"`
+	Message   string        `json:"message"`
+	Details   []interface{} `json:"details"`
+	RequestID string        `json:"requestId,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	msg := http.StatusText(e.StatusCode)
+	if msg == "" {
+		msg = fmt.Sprintf("HTTP %d", e.StatusCode)
+	}
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s, message: %s", msg, e.Message)
+	}
+	if e.RequestID != "" {
+		msg = fmt.Sprintf("%s (request ID: %s)", msg, e.RequestID)
+	}
+	return msg
+}
+
+// Is reports a not found response as ErrNotFound.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// IsNotFound reports whether err is, or wraps, an API error for a missing resource.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsRetryable reports whether err is, or wraps, an API error for a transient failure
+// the same request may not hit again.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// MapHttpError returns the *APIError for a response with the given status and body.
+func MapHttpError(code int, retval []byte) error {
+	apiErr := &APIError{}
+	if err := json.Unmarshal(retval, apiErr); err != nil {
+		// not a json error response, keep whatever the service sent
+		apiErr = &APIError{Message: strings.TrimSpace(string(retval))}
+	}
+	apiErr.StatusCode = code
+	return apiErr
+}
+
+// ResponseError returns the *APIError for an http response that is not a success,
+// including its request ID.
+func ResponseError(resp *http.Response, body []byte) error {
+	return MapHttpError(resp.StatusCode, withRequestID(resp, body))
+}
+
+// withRequestID adds the request ID header of an error response to its body, where
+// MapHttpError picks it up.
+func withRequestID(resp *http.Response, body []byte) []byte {
+	requestID := resp.Header.Get(requestIDHeader)
+	if resp.StatusCode < http.StatusBadRequest || requestID == "" {
+		return body
+	}
+
+	errBody := map[string]any{}
+	if err := json.Unmarshal(body, &errBody); err != nil {
+		errBody = map[string]any{"message": strings.TrimSpace(string(body))}
+	}
+	if _, ok := errBody["requestId"]; !ok {
+		errBody["requestId"] = requestID
+	}
+	annotated, err := json.Marshal(errBody)
+	if err != nil {
+		return body
+	}
+	return annotated
+}
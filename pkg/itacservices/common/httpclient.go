@@ -3,13 +3,24 @@ package common
 import (
 	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// apiCallAttempts is how many times an API call is sent when the connection fails.
+	apiCallAttempts = 3
+	// apiCallRetryInterval is the wait between two attempts of an API call.
+	apiCallRetryInterval = 5 * time.Second
 )
 
+// defaultHTTPClient sends the API calls made without a client of their own.
+var defaultHTTPClient = &http.Client{Timeout: TransportConfig{}.requestTimeout()}
+
 type APIClient interface {
 	MakeGetAPICall(ctx context.Context, url, token string, headers map[string]string) (int, []byte, error)
 	MakePOSTAPICall(ctx context.Context, url, token string, payload []byte) (int, []byte, error)
@@ -20,174 +31,99 @@ type APIClient interface {
 }
 
 // MakeGetAPICall :
-func MakeGetAPICall(ctx context.Context, connURL, auth string, payload []byte) (int, []byte, error) {
-
-	req, err := http.NewRequest("GET", connURL, bytes.NewBuffer(payload))
-	if err != nil {
-		return http.StatusInternalServerError, nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	if auth != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", auth))
-	}
-	printRequest(req)
-	retries := 3
-	body := []byte{}
-	retcode := http.StatusOK
-	for try := 1; try <= retries; try++ {
-		client := &http.Client{Timeout: 60 * time.Second}
-		resp, err := client.Do(req)
-		if err != nil {
-			if try == retries {
-				return http.StatusInternalServerError, nil,
-					errors.New("error conencting to  api service")
-			}
-			time.Sleep(5 * time.Second)
-			continue
-		}
-		defer resp.Body.Close()
-		body, _ = io.ReadAll(resp.Body)
-		retcode = resp.StatusCode
-		break
-	}
-	return retcode, body, nil
+func MakeGetAPICall(ctx context.Context, httpClient *http.Client, connURL, auth string, payload []byte) (int, []byte, error) {
+	return makeAPICall(ctx, httpClient, http.MethodGet, connURL, auth, payload)
 }
 
 // MakePOSTAPICall :
-func MakePOSTAPICall(ctx context.Context, connURL, auth string, payload []byte) (int, []byte, error) {
-
-	req, err := http.NewRequest("POST", connURL, bytes.NewBuffer(payload))
-	if err != nil {
-		return http.StatusInternalServerError, nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", auth))
-	if auth != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", auth))
-	}
-	printRequest(req)
-	retries := 3
-	body := []byte{}
-	retcode := http.StatusOK
-	for try := 1; try <= retries; try++ {
-		client := &http.Client{Timeout: 60 * time.Second}
-		resp, err := client.Do(req)
-		if err != nil {
-			if try == retries {
-				return http.StatusInternalServerError, nil,
-					errors.New("error conencting to  api service")
-			}
-			time.Sleep(5 * time.Second)
-			continue
-		}
-		defer resp.Body.Close()
-		body, _ = io.ReadAll(resp.Body)
-		retcode = resp.StatusCode
-		break
-	}
-	return retcode, body, nil
+func MakePOSTAPICall(ctx context.Context, httpClient *http.Client, connURL, auth string, payload []byte) (int, []byte, error) {
+	return makeAPICall(ctx, httpClient, http.MethodPost, connURL, auth, payload)
 }
 
 // MakeDeleteAPICall :
-func MakeDeleteAPICall(ctx context.Context, connURL string, auth string, payload []byte) (int, []byte, error) {
-	req, err := http.NewRequest("DELETE", connURL, bytes.NewBuffer(payload))
-	if err != nil {
-		return http.StatusInternalServerError, nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	if auth != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", auth))
-	}
-	printRequest(req)
-	retries := 3
-	body := []byte{}
-	retcode := http.StatusOK
-	for try := 1; try <= retries; try++ {
-		client := &http.Client{Timeout: 60 * time.Second}
-		resp, err := client.Do(req)
-		if err != nil {
-			if try == retries {
-				return http.StatusInternalServerError, nil,
-					errors.New("error conencting to  api service")
-			}
-			time.Sleep(5 * time.Second)
-			continue
-		}
-		defer resp.Body.Close()
-		body, _ = io.ReadAll(resp.Body)
-		retcode = resp.StatusCode
-		break
-	}
-	return retcode, body, nil
+func MakeDeleteAPICall(ctx context.Context, httpClient *http.Client, connURL string, auth string, payload []byte) (int, []byte, error) {
+	return makeAPICall(ctx, httpClient, http.MethodDelete, connURL, auth, payload)
 }
 
 // MakePutAPICall :
-func MakePutAPICall(ctx context.Context, connURL, auth string, payload []byte) (int, []byte, error) {
-	req, err := http.NewRequest("PUT", connURL, bytes.NewBuffer(payload))
-	if err != nil {
-		return http.StatusInternalServerError, nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	if auth != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", auth))
+func MakePutAPICall(ctx context.Context, httpClient *http.Client, connURL, auth string, payload []byte) (int, []byte, error) {
+	return makeAPICall(ctx, httpClient, http.MethodPut, connURL, auth, payload)
+}
+
+// makeAPICall sends an API call through httpClient, the shared default client when nil,
+// and returns the status and body of the response. A call whose connection fails is sent
+// again with a new request, as the failed attempt may have consumed the body.
+func makeAPICall(ctx context.Context, httpClient *http.Client, method, connURL, auth string, payload []byte) (int, []byte, error) {
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
 	}
-	printRequest(req)
-	retries := 3
-	body := []byte{}
-	retcode := http.StatusOK
-	for try := 1; try <= retries; try++ {
-		client := &http.Client{Timeout: 60 * time.Second}
-		resp, err := client.Do(req)
+
+	var err error
+	for try := 1; try <= apiCallAttempts; try++ {
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, method, connURL, bytes.NewReader(payload))
 		if err != nil {
-			if try == retries {
-				return http.StatusInternalServerError, nil,
-					errors.New("error conencting to  api service")
-			}
-			time.Sleep(5 * time.Second)
-			continue
+			return http.StatusInternalServerError, nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if method == http.MethodPost {
+			req.Header.Set("Accept", "application/json")
+		}
+		if auth != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", auth))
+		}
+		if try == 1 {
+			tflog.Debug(ctx, "sending api request", map[string]any{"method": method, "url": connURL})
 		}
-		defer resp.Body.Close()
-		body, _ = io.ReadAll(resp.Body)
-		retcode = resp.StatusCode
-		break
-	}
-	return retcode, body, nil
-}
-
-func printRequest(req *http.Request) {
-	fmt.Printf("Method: %s\nURL: %s\nHeaders: %v\n", req.Method, req.URL.String(), req.Header)
 
-	if req.Body != nil {
-		bodyBytes, _ := io.ReadAll(req.Body)
-		fmt.Printf("Body: %s\n", string(bodyBytes))
+		var resp *http.Response
+		resp, err = httpClient.Do(req)
+		if err == nil {
+			body, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if readErr == nil {
+				return resp.StatusCode, withRequestID(resp, body), nil
+			}
+			err = readErr
+		}
+		if try == apiCallAttempts || ctx.Err() != nil {
+			break
+		}
 
-		// Recreate the body since io.ReadAll drains it
-		req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+		timer := time.NewTimer(apiCallRetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
 	}
+	return http.StatusInternalServerError, nil, fmt.Errorf("error connecting to api service: %w", err)
 }
 
-type apiClientImpl struct{}
+type apiClientImpl struct {
+	httpClient *http.Client
+}
 
-// NewAPIClient returns a concrete implementation of the APIClient interface.
-func NewAPIClient() APIClient {
-	return &apiClientImpl{}
+// NewAPIClient returns a concrete implementation of the APIClient interface sending its
+// requests through httpClient, a shared default client when nil.
+func NewAPIClient(httpClient *http.Client) APIClient {
+	return &apiClientImpl{httpClient: httpClient}
 }
 
 func (c *apiClientImpl) MakeGetAPICall(ctx context.Context, url, token string, headers map[string]string) (int, []byte, error) {
-	return MakeGetAPICall(ctx, url, token, nil)
+	return MakeGetAPICall(ctx, c.httpClient, url, token, nil)
 }
 
 func (c *apiClientImpl) MakePOSTAPICall(ctx context.Context, url, token string, payload []byte) (int, []byte, error) {
-	return MakePOSTAPICall(ctx, url, token, payload)
+	return MakePOSTAPICall(ctx, c.httpClient, url, token, payload)
 }
 
 func (c *apiClientImpl) MakePutAPICall(ctx context.Context, url, token string, payload []byte) (int, []byte, error) {
-	return MakePutAPICall(ctx, url, token, payload)
+	return MakePutAPICall(ctx, c.httpClient, url, token, payload)
 }
 
 func (c *apiClientImpl) MakeDeleteAPICall(ctx context.Context, url, token string, headers map[string]string) (int, []byte, error) {
-	return MakeDeleteAPICall(ctx, url, token, nil)
+	return MakeDeleteAPICall(ctx, c.httpClient, url, token, nil)
 }
 
 func (c *apiClientImpl) GenerateFilesystemLoginCredentials(ctx context.Context, resourceId string) (*string, error) {
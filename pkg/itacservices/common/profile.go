@@ -0,0 +1,118 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultProfileName is the profile used when none is selected.
+const DefaultProfileName = "default"
+
+// ErrProfileNotFound is returned by LoadProfile when the file has no profile of the name.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Profile is a named set of provider settings of a profile file.
+type Profile struct {
+	Name         string
+	Region       string
+	Cloudaccount string
+	ClientID     string
+	ClientSecret string
+	APIEndpoint  string
+	AuthEndpoint string
+	// CredentialProcess is the command printing the credentials, in place of ClientID and
+	// ClientSecret.
+	CredentialProcess string
+	// Ignored are the keys of the profile the provider does not know, e.g. settings of other
+	// tools sharing the file.
+	Ignored []string
+}
+
+// DefaultProfileFile returns the path of the profile file, ~/.intelcloud/config.
+func DefaultProfileFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error locating profile file: %w", err)
+	}
+	return filepath.Join(home, ".intelcloud", "config"), nil
+}
+
+// LoadProfile reads the profile of the given name from an INI style profile file, where each
+// profile is a [name] section of key = value settings, e.g.
+//
+//	[default]
+//	region        = us-region-1
+//	cloudaccount  = 123456789012
+//	client_id     = ...
+//	client_secret = ...
+//	api_endpoint  = https://us-region-1-sdk-api.cloud.intel.com
+//	auth_endpoint = https://client-token.api.idcservice.net
+//
+// A credential_process command may be given in place of client_id and client_secret.
+// Sections may also be written [profile name]. Lines starting with # or ; are comments. Unknown
+// keys are skipped and listed in Ignored.
+func LoadProfile(file, name string) (*Profile, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading profile file: %w", err)
+	}
+
+	var profile *Profile
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			if profile != nil {
+				// the profile ends where the next section starts
+				break
+			}
+			section := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line[1:len(line)-1]), "profile "))
+			if section == name {
+				profile = &Profile{Name: name}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("error parsing profile file %s: line %d is not a key = value setting", file, lineNo)
+		}
+		if profile == nil {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch strings.TrimSpace(key) {
+		case "region":
+			profile.Region = value
+		case "cloudaccount":
+			profile.Cloudaccount = value
+		case "client_id":
+			profile.ClientID = value
+		case "client_secret":
+			profile.ClientSecret = value
+		case "api_endpoint":
+			profile.APIEndpoint = value
+		case "auth_endpoint":
+			profile.AuthEndpoint = value
+		case "credential_process":
+			profile.CredentialProcess = value
+		default:
+			profile.Ignored = append(profile.Ignored, strings.TrimSpace(key))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading profile file: %w", err)
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("%w: no profile %q in %s", ErrProfileNotFound, name, file)
+	}
+	return profile, nil
+}
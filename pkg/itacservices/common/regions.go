@@ -0,0 +1,142 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultAuthEndpoint is the token service shared by the built-in regions.
+const defaultAuthEndpoint = "https://client-token.api.idcservice.net"
+
+// Region is an ITAC region with its service endpoints and availability zones.
+type Region struct {
+	Name         string   `json:"name" yaml:"name"`
+	APIEndpoint  string   `json:"api" yaml:"api"`
+	AuthEndpoint string   `json:"auth" yaml:"auth"`
+	Zones        []string `json:"zones" yaml:"zones"`
+
+	// builtin marks the built-in regions, which only list their default zone.
+	builtin bool
+}
+
+// DefaultZone returns the zone used when a resource does not name one, the first zone
+// of the region.
+func (r *Region) DefaultZone() string {
+	if len(r.Zones) == 0 {
+		return ""
+	}
+	return r.Zones[0]
+}
+
+// ValidateZone checks that the availability zone belongs to the region. A region of a
+// catalog file accepts the zones it lists, a built-in region any zone named after it.
+func (r *Region) ValidateZone(zone string) error {
+	if slices.Contains(r.Zones, zone) {
+		return nil
+	}
+	if r.builtin {
+		return ValidateAvailabilityZone(r.Name, zone)
+	}
+	return fmt.Errorf("availability zone %q does not belong to region %q, expected one of: %s",
+		zone, r.Name, strings.Join(r.Zones, ", "))
+}
+
+// RegionCatalog lists the regions the provider can reach.
+type RegionCatalog struct {
+	regions map[string]Region
+}
+
+// regionCatalogFile is the layout of a region catalog file.
+type regionCatalogFile struct {
+	Regions []Region `json:"regions" yaml:"regions"`
+}
+
+// builtinRegions are the public ITAC regions.
+var builtinRegions = []Region{
+	{Name: "us-region-1", APIEndpoint: "https://us-region-1-sdk-api.cloud.intel.com", AuthEndpoint: defaultAuthEndpoint, Zones: []string{"us-region-1a"}, builtin: true},
+	{Name: "us-region-2", APIEndpoint: "https://us-region-2-sdk-api.cloud.intel.com", AuthEndpoint: defaultAuthEndpoint, Zones: []string{"us-region-2a"}, builtin: true},
+	{Name: "us-region-3", APIEndpoint: "https://us-region-3-sdk-api.cloud.intel.com", AuthEndpoint: defaultAuthEndpoint, Zones: []string{"us-region-3a"}, builtin: true},
+	{Name: "us-region-4", APIEndpoint: "https://us-region-4-sdk-api.cloud.intel.com", AuthEndpoint: defaultAuthEndpoint, Zones: []string{"us-region-4a"}, builtin: true},
+	{Name: "us-region-5", APIEndpoint: "https://us-region-5-sdk-api.cloud.intel.com", AuthEndpoint: defaultAuthEndpoint, Zones: []string{"us-region-5a"}, builtin: true},
+}
+
+// DefaultRegionCatalog returns the catalog of the built-in regions.
+func DefaultRegionCatalog() *RegionCatalog {
+	catalog := &RegionCatalog{regions: map[string]Region{}}
+	for _, r := range builtinRegions {
+		catalog.regions[r.Name] = r
+	}
+	return catalog
+}
+
+// LoadRegionCatalog returns the built-in regions overridden by source, which is either the
+// path of a JSON or YAML catalog file or the catalog itself. A region of source replaces
+// the built-in region of the same name, and an empty source keeps the built-in catalog.
+func LoadRegionCatalog(source string) (*RegionCatalog, error) {
+	catalog := DefaultRegionCatalog()
+	if strings.TrimSpace(source) == "" {
+		return catalog, nil
+	}
+
+	data, isJSON := []byte(source), strings.HasPrefix(strings.TrimSpace(source), "{")
+	if !isJSON && !strings.Contains(source, "\n") {
+		var err error
+		if data, err = os.ReadFile(source); err != nil {
+			return nil, fmt.Errorf("error reading region catalog: %w", err)
+		}
+		isJSON = strings.EqualFold(filepath.Ext(source), ".json")
+	}
+
+	var file regionCatalogFile
+	var err error
+	if isJSON {
+		err = json.Unmarshal(data, &file)
+	} else {
+		err = yaml.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing region catalog: %w", err)
+	}
+
+	for i, r := range file.Regions {
+		if r.Name == "" || r.APIEndpoint == "" || r.AuthEndpoint == "" || len(r.Zones) == 0 {
+			return nil, fmt.Errorf("region catalog entry %d must set name, api, auth and zones", i)
+		}
+		catalog.regions[r.Name] = r
+	}
+	return catalog, nil
+}
+
+// Region returns the region of the given name.
+func (c *RegionCatalog) Region(name string) (*Region, error) {
+	r, ok := c.regions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown region %q, expected one of: %s", name, strings.Join(c.Names(), ", "))
+	}
+	return &r, nil
+}
+
+// Names returns the names of the regions in the catalog, sorted.
+func (c *RegionCatalog) Names() []string {
+	names := make([]string, 0, len(c.regions))
+	for name := range c.regions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Regions returns the regions in the catalog, sorted by name.
+func (c *RegionCatalog) Regions() []Region {
+	regions := make([]Region, 0, len(c.regions))
+	for _, name := range c.Names() {
+		regions = append(regions, c.regions[name])
+	}
+	return regions
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	SSHKeyTypeED25519 = "ed25519"
+	SSHKeyTypeRSA     = "rsa"
+
+	sshRSAKeyBits = 4096
+)
+
+// GenerateSSHKeyPair generates a keypair of the given type and returns the public key in
+// authorized_keys format and the private key as an OpenSSH PEM block.
+func GenerateSSHKeyPair(keyType string) (string, string, error) {
+	var publicKey crypto.PublicKey
+	var privateKey crypto.PrivateKey
+
+	switch keyType {
+	case SSHKeyTypeED25519:
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return "", "", fmt.Errorf("error generating ed25519 key: %w", err)
+		}
+		publicKey, privateKey = pub, priv
+	case SSHKeyTypeRSA:
+		priv, err := rsa.GenerateKey(rand.Reader, sshRSAKeyBits)
+		if err != nil {
+			return "", "", fmt.Errorf("error generating rsa key: %w", err)
+		}
+		publicKey, privateKey = &priv.PublicKey, priv
+	default:
+		return "", "", fmt.Errorf("unsupported ssh key type %q", keyType)
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return "", "", fmt.Errorf("error encoding ssh public key: %w", err)
+	}
+	pemBlock, err := ssh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		return "", "", fmt.Errorf("error encoding ssh private key: %w", err)
+	}
+
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPublicKey)))
+	return authorizedKey, string(pem.EncodeToMemory(pemBlock)), nil
+}
+
+// SSHKeyFingerprint parses a public key in authorized_keys format and returns its SHA256 fingerprint.
+func SSHKeyFingerprint(publicKey string) (string, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return "", fmt.Errorf("error parsing ssh public key: %w", err)
+	}
+	return ssh.FingerprintSHA256(key), nil
+}
@@ -0,0 +1,176 @@
+package common
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	// DefaultDialTimeout bounds establishing the TCP connection of an API call.
+	DefaultDialTimeout = 30 * time.Second
+	// DefaultTLSHandshakeTimeout bounds the TLS handshake of a new connection.
+	DefaultTLSHandshakeTimeout = 10 * time.Second
+	// DefaultResponseHeaderTimeout bounds the wait for the response headers once a request
+	// is sent.
+	DefaultResponseHeaderTimeout = 60 * time.Second
+	// DefaultBodyReadTimeout is the time left to read the response body when the timeout of
+	// a whole request is derived from the step timeouts.
+	DefaultBodyReadTimeout = 30 * time.Second
+
+	// maxIdleConnsPerHost keeps enough connections to the ITAC API alive for the parallel
+	// operations of a plan, where http.DefaultTransport keeps 2.
+	maxIdleConnsPerHost = 32
+)
+
+// TransportConfig holds the proxy and TLS settings of the transport shared by every ITAC
+// API call of a client. The zero value proxies as the environment says and verifies the
+// server against the system roots.
+type TransportConfig struct {
+	// ProxyURL is the proxy for both http and https requests. When empty the HTTP_PROXY,
+	// HTTPS_PROXY and NO_PROXY environment variables apply.
+	ProxyURL string
+	// NoProxy lists the hosts reached without the proxy, in NO_PROXY format. It replaces
+	// the NO_PROXY environment variable when set.
+	NoProxy string
+
+	// CACertFile and CACertPEM add PEM encoded CA certificates to the system roots.
+	CACertFile string
+	CACertPEM  string
+	// ClientCert and ClientKey are the PEM encoded certificate and key presented to the
+	// server, or the paths of files holding them.
+	ClientCert string
+	ClientKey  string
+
+	InsecureSkipVerify bool
+
+	// DialTimeout, TLSHandshakeTimeout and ResponseHeaderTimeout bound the steps of a
+	// request. Each defaults to its Default constant when zero.
+	DialTimeout           time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	// RequestTimeout bounds a whole request, from dialing to reading the body. When zero it
+	// is the sum of the step timeouts and DefaultBodyReadTimeout, so raising a step timeout
+	// is not undone by a shorter overall limit.
+	RequestTimeout time.Duration
+}
+
+// requestTimeout returns the timeout of a whole request for cfg.
+func (cfg TransportConfig) requestTimeout() time.Duration {
+	if cfg.RequestTimeout > 0 {
+		return cfg.RequestTimeout
+	}
+	return withDefault(cfg.DialTimeout, DefaultDialTimeout) +
+		withDefault(cfg.TLSHandshakeTimeout, DefaultTLSHandshakeTimeout) +
+		withDefault(cfg.ResponseHeaderTimeout, DefaultResponseHeaderTimeout) +
+		DefaultBodyReadTimeout
+}
+
+// NewHTTPClient returns the long-lived client of an IDCServicesClient, pooling its
+// connections in a transport built for cfg.
+func NewHTTPClient(cfg TransportConfig) (*http.Client, error) {
+	transport, err := NewTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Timeout: cfg.requestTimeout(), Transport: transport}, nil
+}
+
+// NewTransport builds the http transport for cfg.
+func NewTransport(cfg TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	transport.DialContext = (&net.Dialer{
+		Timeout:   withDefault(cfg.DialTimeout, DefaultDialTimeout),
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = withDefault(cfg.TLSHandshakeTimeout, DefaultTLSHandshakeTimeout)
+	transport.ResponseHeaderTimeout = withDefault(cfg.ResponseHeaderTimeout, DefaultResponseHeaderTimeout)
+
+	proxy := httpproxy.FromEnvironment()
+	if cfg.ProxyURL != "" {
+		if _, err := url.Parse(cfg.ProxyURL); err != nil {
+			return nil, fmt.Errorf("error parsing proxy url: %w", err)
+		}
+		proxy.HTTPProxy = cfg.ProxyURL
+		proxy.HTTPSProxy = cfg.ProxyURL
+	}
+	if cfg.NoProxy != "" {
+		proxy.NoProxy = cfg.NoProxy
+	}
+	proxyFunc := proxy.ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CACertFile != "" || cfg.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if cfg.CACertFile != "" {
+			pem, err := os.ReadFile(cfg.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("error reading ca certificate file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in ca certificate file %s", cfg.CACertFile)
+			}
+		}
+		if cfg.CACertPEM != "" && !pool.AppendCertsFromPEM([]byte(cfg.CACertPEM)) {
+			return nil, fmt.Errorf("no certificates found in ca certificate pem")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		if cfg.ClientCert == "" || cfg.ClientKey == "" {
+			return nil, fmt.Errorf("client certificate and client key must be set together")
+		}
+		certPEM, err := readPEM(cfg.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client certificate: %w", err)
+		}
+		keyPEM, err := readPEM(cfg.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client key: %w", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// readPEM returns value when it holds PEM data, and otherwise the content of the file it
+// names.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
+
+func withDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
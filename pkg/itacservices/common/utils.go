@@ -2,21 +2,9 @@ package common
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"strings"
 	"text/template"
-	"time"
-)
-
-type APIError struct {
-	Code    int           `json:"code"`
-	Message string        `json:"message"`
-	Details []interface{} `json:"details"`
-}
-
-const (
-	DefaultRetryInterval = time.Duration(5 * time.Second)
 )
 
 // ParseString parses the given template string with the provided data.
@@ -35,31 +23,17 @@ func ParseString(templateString string, data interface{}) (string, error) {
 	return result.String(), nil
 }
 
-func MapHttpError(code int, retval []byte) error {
-	switch code {
-	case http.StatusUnauthorized:
-		return fmt.Errorf("Unauthorized")
-	case http.StatusBadRequest:
-		return fmt.Errorf("Bad Request, message: %v", mapAPIErrorMessage(retval))
-	case http.StatusInternalServerError:
-		return fmt.Errorf("Internal Server Error, message: %v", mapAPIErrorMessage(retval))
-	default:
-		return fmt.Errorf("error calling API, message: %v", mapAPIErrorMessage(retval))
-	}
+// GetDefaultVnetName returns the name of the vnet the provider creates in an availability zone.
+func GetDefaultVnetName(availabilityZone string) string {
+	return fmt.Sprintf("%s-default", availabilityZone)
 }
 
-func mapAPIErrorMessage(retval []byte) error {
-	apiError := APIError{}
-	if err := json.Unmarshal(retval, &apiError); err != nil {
-		return fmt.Errorf("error parsing iks error response")
+// ValidateAvailabilityZone checks that the availability zone belongs to the region,
+// zones being named after their region followed by a zone letter, e.g. us-region-1a.
+func ValidateAvailabilityZone(region, availabilityZone string) error {
+	suffix, found := strings.CutPrefix(availabilityZone, region)
+	if !found || len(suffix) != 1 || suffix[0] < 'a' || suffix[0] > 'z' {
+		return fmt.Errorf("availability zone %q does not belong to region %q", availabilityZone, region)
 	}
-	return fmt.Errorf("%v", apiError.Message)
-}
-
-func GetAvailabiltyZoneAndVnet(region string) (string, string) {
-	availabilityZone := fmt.Sprintf("%sa", region)
-	vnetName := fmt.Sprintf("%s-default", availabilityZone)
-
-	return availabilityZone, vnetName
-
+	return nil
 }
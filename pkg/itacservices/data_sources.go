@@ -4,10 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"terraform-provider-intelcloud/pkg/itacservices/common"
-
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
@@ -16,29 +13,35 @@ var (
 )
 
 type MachineImageResponse struct {
-	Items []struct {
-		Metadata struct {
-			Name string `json:"name"`
-		} `json:"metadata"`
-		Spec struct {
-			Description        string   `json:"description"`
-			InstanceCategories []string `json:"instanceCategories"`
-			InstanceTypes      []string `json:"instanceTypes"`
-		} `json:"spec"`
-		Hidden bool `json:"hidden"`
-	} `json:"items"`
+	Items         []MachineImage `json:"items"`
+	NextPageToken string         `json:"nextPageToken,omitempty"`
+}
+
+type MachineImage struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Description        string   `json:"description"`
+		InstanceCategories []string `json:"instanceCategories"`
+		InstanceTypes      []string `json:"instanceTypes"`
+	} `json:"spec"`
+	Hidden bool `json:"hidden"`
 }
 
 type InstanceTypeResponse struct {
-	Items []struct {
-		Metadata struct {
-			Name string `json:"name"`
-		} `json:"metadata"`
-		Spec struct {
-			Description      string `json:"description"`
-			InstanceCategory string `json:"instanceCategory"`
-		} `json:"spec"`
-	} `json:"items"`
+	Items         []InstanceType `json:"items"`
+	NextPageToken string         `json:"nextPageToken,omitempty"`
+}
+
+type InstanceType struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Description      string `json:"description"`
+		InstanceCategory string `json:"instanceCategory"`
+	} `json:"spec"`
 }
 
 func (client *IDCServicesClient) GetMachineImages(ctx context.Context) (*MachineImageResponse, error) {
@@ -51,23 +54,17 @@ func (client *IDCServicesClient) GetMachineImages(ctx context.Context) (*Machine
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(getAllMachineImagesURL, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to list machine images: %w", err)
 	}
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	items, err := newPageIterator("machine images", parsedURL, client.commonGet, func(retval []byte) ([]MachineImage, string, error) {
+		page := MachineImageResponse{}
+		err := json.Unmarshal(retval, &page)
+		return page.Items, page.NextPageToken, err
+	}).All(ctx)
 	if err != nil {
-		tflog.Debug(ctx, "machine images api error", map[string]any{"retcode": retcode, "err": err, "token": *client.Apitoken})
-		return nil, fmt.Errorf("error reading machine images")
-	}
-	tflog.Debug(ctx, "machine images api", map[string]any{"retcode": retcode, "retval": string(retval), "token": *client.Apitoken})
-	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, err
 	}
-
-	images := MachineImageResponse{}
-	if err := json.Unmarshal(retval, &images); err != nil {
-		return nil, fmt.Errorf("error parsing machine image response")
-	}
-	return &images, nil
+	return &MachineImageResponse{Items: items}, nil
 }
 
 func (client *IDCServicesClient) GetInstanceTypes(ctx context.Context) (*InstanceTypeResponse, error) {
@@ -80,21 +77,16 @@ func (client *IDCServicesClient) GetInstanceTypes(ctx context.Context) (*Instanc
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(getAllInstanceTypesURL, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to list instance types: %w", err)
 	}
 
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	items, err := newPageIterator("instance types", parsedURL, client.commonGet, func(retval []byte) ([]InstanceType, string, error) {
+		page := InstanceTypeResponse{}
+		err := json.Unmarshal(retval, &page)
+		return page.Items, page.NextPageToken, err
+	}).All(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error reading machine images")
-	}
-
-	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
-	}
-	instType := InstanceTypeResponse{}
-	if err := json.Unmarshal(retval, &instType); err != nil {
-		return nil, fmt.Errorf("error parsing machine image response")
+		return nil, err
 	}
-
-	return &instType, nil
+	return &InstanceTypeResponse{Items: items}, nil
 }
@@ -5,11 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"terraform-provider-intelcloud/pkg/itacservices/common"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	retry "github.com/sethvargo/go-retry"
 )
 
 var (
@@ -17,12 +17,20 @@ var (
 	createFilesystemsURL         = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/filesystems"
 	updateFilesystemByName       = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/filesystems/name/{{.Name}}"
 	getFilesystemByResourceId    = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/filesystems/id/{{.ResourceId}}"
+	getFilesystemByName          = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/filesystems/name/{{.Name}}"
 	deleteFilesystemByResourceId = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/filesystems/id/{{.ResourceId}}"
 	getLoginCredentials          = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/filesystems/id/{{.ResourceId}}/user"
 )
 
+const (
+	FilesystemPhaseProvisioning = "FSProvisioning"
+	FilesystemPhaseReady        = "FSReady"
+	FilesystemPhaseFailed       = "FSFailed"
+)
+
 type Filesystems struct {
 	FilesystemList []Filesystem `json:"items"`
+	NextPageToken  string       `json:"nextPageToken,omitempty"`
 }
 
 type Filesystem struct {
@@ -44,8 +52,9 @@ type Filesystem struct {
 		AvailabilityZone string `json:"availabilityZone"`
 	} `json:"spec"`
 	Status struct {
-		Phase string `json:"phase"`
-		Mount struct {
+		Phase   string `json:"phase"`
+		Message string `json:"message"`
+		Mount   struct {
 			ClusterAddr    string `json:"clusterAddr"`
 			ClusterVersion string `json:"clusterVersion"`
 			Namespace      string `json:"namespace"`
@@ -105,22 +114,18 @@ func (client *IDCServicesClient) GetFilesystems(ctx context.Context) (*Filesyste
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(getAllFilesystemsURL, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to list filesystems: %w", err)
 	}
 
-	retcode, retval, err := client.APIClient.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
-	tflog.Debug(ctx, "filesystem read api", map[string]any{"retcode": retcode, "retval": string(retval)})
+	items, err := newPageIterator("filesystems", parsedURL, client.apiClientGet, func(retval []byte) ([]Filesystem, string, error) {
+		page := Filesystems{}
+		err := json.Unmarshal(retval, &page)
+		return page.FilesystemList, page.NextPageToken, err
+	}).All(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error reading filesystems")
-	}
-
-	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
-	}
-	filesystems := Filesystems{}
-	if err := json.Unmarshal(retval, &filesystems); err != nil {
-		return nil, fmt.Errorf("error parsing filesystem response")
+		return nil, err
 	}
+	filesystems := Filesystems{FilesystemList: items}
 
 	var password *string
 	if len(filesystems.FilesystemList) != 0 {
@@ -129,7 +134,7 @@ func (client *IDCServicesClient) GetFilesystems(ctx context.Context) (*Filesyste
 		// get login credentials
 		password, err = client.GenerateFilesystemLoginCredentials(ctx, filesystems.FilesystemList[0].Metadata.ResourceId)
 		if err != nil {
-			return nil, fmt.Errorf("error generating filesystem login credentials")
+			return nil, err
 		}
 	}
 
@@ -153,19 +158,19 @@ func (client *IDCServicesClient) GenerateFilesystemLoginCredentials(ctx context.
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(getLoginCredentials, getLoginParams)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to generate credentials for filesystem %s: %w", resourceId, err)
 	}
 
-	retcode, retval, err := client.APIClient.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := client.APIClient.MakeGetAPICall(ctx, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return nil, fmt.Errorf("error generating login credentials")
+		return nil, fmt.Errorf("error generating credentials for filesystem %s: %w", resourceId, err)
 	}
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error generating credentials for filesystem %s: %w", resourceId, common.MapHttpError(retcode, retval))
 	}
 	creds := LoginCreds{}
 	if err := json.Unmarshal(retval, &creds); err != nil {
-		return nil, fmt.Errorf("error parsing filesystem credentials response")
+		return nil, fmt.Errorf("error parsing credentials response for filesystem %s: %w", resourceId, err)
 	}
 	return &creds.Password, nil
 }
@@ -182,45 +187,37 @@ func (client *IDCServicesClient) CreateFilesystem(ctx context.Context, in *Files
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(createFilesystemsURL, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to create filesystem %s: %w", in.Metadata.Name, err)
 	}
 
 	inArgs, err := json.MarshalIndent(in, "", "    ")
 	if err != nil {
-		return nil, fmt.Errorf("error parsing input arguments")
+		return nil, fmt.Errorf("error encoding create request for filesystem %s: %w", in.Metadata.Name, err)
 	}
 
 	tflog.Debug(ctx, "filesystem create api", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
-	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, *client.Apitoken, inArgs)
+	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, client.accessToken(ctx), inArgs)
 	tflog.Debug(ctx, "filesystem create api", map[string]any{"retcode": retcode, "retval": string(retval)})
 	if err != nil {
-		return nil, fmt.Errorf("error reading filesystem create response")
+		return nil, fmt.Errorf("error creating filesystem %s: %w", in.Metadata.Name, err)
 	}
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error creating filesystem %s: %w", in.Metadata.Name, common.MapHttpError(retcode, retval))
 	}
 
 	filesystem := &Filesystem{}
 	if err := json.Unmarshal(retval, filesystem); err != nil {
-		return nil, fmt.Errorf("error parsing filesystem response")
+		return nil, fmt.Errorf("error parsing create response for filesystem %s: %w", in.Metadata.Name, err)
 	}
+	resourceId := filesystem.Metadata.ResourceId
 
-	backoffTimer := retry.NewConstant(common.DefaultRetryInterval)
-
-	if err := retry.Do(ctx, backoffTimer, func(_ context.Context) error {
-		filesystem, err = client.GetFilesystemByResourceId(ctx, filesystem.Metadata.ResourceId)
-		if err != nil {
-			return fmt.Errorf("error reading filesystem state")
-		}
-		if filesystem.Status.Phase == "FSReady" {
-			return nil
-		} else if filesystem.Status.Phase == "FSFailed" {
-			return fmt.Errorf("filesystem state failed")
-		} else {
-			return retry.RetryableError(fmt.Errorf("filesystem state not ready, retry again"))
-		}
-	}); err != nil {
-		return nil, fmt.Errorf("filesystem state not ready after maximum retries")
+	waiter := client.filesystemWaiter(resourceId)
+	waiter.Pending = []string{"", FilesystemPhaseProvisioning}
+	waiter.Target = []string{FilesystemPhaseReady}
+	waiter.Failure = []string{FilesystemPhaseFailed}
+	filesystem, err = waiter.Wait(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// tflog.Debug(ctx, "filesystem generate passwordi", map[string]any{"resource": filesystem.Metadata.ResourceId})
@@ -236,6 +233,32 @@ func (client *IDCServicesClient) CreateFilesystem(ctx context.Context, in *Files
 	return filesystem, nil
 }
 
+// WaitForFilesystemDeleted waits until the filesystem is gone after a delete request.
+func (client *IDCServicesClient) WaitForFilesystemDeleted(ctx context.Context, resourceId string) error {
+	waiter := client.filesystemWaiter(resourceId)
+	waiter.Goal = "deleted"
+	waiter.TargetNotFound = true
+	_, err := waiter.Wait(ctx)
+	return err
+}
+
+func (client *IDCServicesClient) filesystemWaiter(resourceId string) *Waiter[Filesystem] {
+	return &Waiter[Filesystem]{
+		Noun: "filesystem",
+		ID:   resourceId,
+		Refresh: func(ctx context.Context) (*Filesystem, string, error) {
+			filesystem, err := client.GetFilesystemByResourceId(ctx, resourceId)
+			if err != nil {
+				return nil, "", err
+			}
+			return filesystem, filesystem.Status.Phase, nil
+		},
+		Message: func(filesystem *Filesystem) string {
+			return filesystem.Status.Message
+		},
+	}
+}
+
 func (client *IDCServicesClient) GetFilesystemByResourceId(ctx context.Context, resourceId string) (*Filesystem, error) {
 	params := struct {
 		Host         string
@@ -250,22 +273,57 @@ func (client *IDCServicesClient) GetFilesystemByResourceId(ctx context.Context,
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(getFilesystemByResourceId, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to read filesystem %s: %w", resourceId, err)
+	}
+
+	retcode, retval, err := client.APIClient.MakeGetAPICall(ctx, parsedURL, client.accessToken(ctx), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error reading filesystem %s: %w", resourceId, err)
+	}
+
+	if retcode != http.StatusOK {
+		return nil, fmt.Errorf("error reading filesystem %s: %w", resourceId, common.MapHttpError(retcode, retval))
+	}
+
+	tflog.Debug(ctx, "filesystem read api", map[string]any{"retcode": retcode})
+	filesystem := Filesystem{}
+	if err := json.Unmarshal(retval, &filesystem); err != nil {
+		return nil, fmt.Errorf("error parsing filesystem %s response: %w", resourceId, err)
+	}
+	return &filesystem, nil
+}
+
+// GetFilesystemByName returns the filesystem with the given name.
+func (client *IDCServicesClient) GetFilesystemByName(ctx context.Context, name string) (*Filesystem, error) {
+	params := struct {
+		Host         string
+		Cloudaccount string
+		Name         string
+	}{
+		Host:         *client.Host,
+		Cloudaccount: *client.Cloudaccount,
+		Name:         url.PathEscape(name),
+	}
+
+	// Parse the template string with the provided data
+	parsedURL, err := client.APIClient.ParseString(getFilesystemByName, params)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the url to read filesystem %s: %w", name, err)
 	}
 
-	retcode, retval, err := client.APIClient.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := client.APIClient.MakeGetAPICall(ctx, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return nil, fmt.Errorf("error reading filesystem by resource id")
+		return nil, fmt.Errorf("error reading filesystem %s: %w", name, err)
 	}
 
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error reading filesystem %s: %w", name, common.MapHttpError(retcode, retval))
 	}
 
 	tflog.Debug(ctx, "filesystem read api", map[string]any{"retcode": retcode})
 	filesystem := Filesystem{}
 	if err := json.Unmarshal(retval, &filesystem); err != nil {
-		return nil, fmt.Errorf("error parsing filesystem response")
+		return nil, fmt.Errorf("error parsing filesystem %s response: %w", name, err)
 	}
 	return &filesystem, nil
 }
@@ -284,18 +342,18 @@ func (client *IDCServicesClient) DeleteFilesystemByResourceId(ctx context.Contex
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(deleteFilesystemByResourceId, params)
 	if err != nil {
-		return fmt.Errorf("error parsing the url")
+		return fmt.Errorf("error parsing the url to delete filesystem %s: %w", resourceId, err)
 	}
 
-	retcode, retval, err := client.APIClient.MakeDeleteAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := client.APIClient.MakeDeleteAPICall(ctx, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return fmt.Errorf("error deleting filesystem by resource id")
+		return fmt.Errorf("error deleting filesystem %s: %w", resourceId, err)
 	}
 
 	tflog.Debug(ctx, "filesystem delete api", map[string]any{"retcode": retcode, "retval": string(retval)})
 
 	if retcode != http.StatusOK {
-		return common.MapHttpError(retcode, retval)
+		return fmt.Errorf("error deleting filesystem %s: %w", resourceId, common.MapHttpError(retcode, retval))
 	}
 
 	return nil
@@ -317,7 +375,7 @@ func (client *IDCServicesClient) UpdateFilesystem(ctx context.Context, in *Files
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(updateFilesystemByName, params)
 	if err != nil {
-		return fmt.Errorf("error parsing the url")
+		return fmt.Errorf("error parsing the url to update filesystem %s: %w", in.Metadata.Name, err)
 	}
 
 	//tflog.Debug(ctx, "filesystem update api", map[string]any{"url": parsedURL, "payload": params.Payload})
@@ -325,19 +383,19 @@ func (client *IDCServicesClient) UpdateFilesystem(ctx context.Context, in *Files
 	// Convert the struct to JSON []byte
 	paramsByte, err := json.Marshal(params.Payload)
 	if err != nil {
-		return fmt.Errorf("error converting payload %v to JSON: %v", params.Payload, err)
+		return fmt.Errorf("error encoding update request for filesystem %s: %w", in.Metadata.Name, err)
 	}
 	tflog.Debug(ctx, "filesystem update api", map[string]any{"url": parsedURL, "payload byte": paramsByte})
 
-	retcode, retval, err := client.APIClient.MakePutAPICall(ctx, parsedURL, *client.Apitoken, paramsByte)
+	retcode, retval, err := client.APIClient.MakePutAPICall(ctx, parsedURL, client.accessToken(ctx), paramsByte)
 	if err != nil {
-		return fmt.Errorf("error updating filesystem by name")
+		return fmt.Errorf("error updating filesystem %s: %w", in.Metadata.Name, err)
 	}
 
 	tflog.Debug(ctx, "filesystem update api", map[string]any{"retcode": retcode, "retval": string(retval), "error": err})
 
 	if retcode != http.StatusOK {
-		return common.MapHttpError(retcode, retval)
+		return fmt.Errorf("error updating filesystem %s: %w", in.Metadata.Name, common.MapHttpError(retcode, retval))
 	}
 
 	return nil
@@ -0,0 +1,137 @@
+package itacservices
+
+import (
+	"context"
+	"encoding/json"
+)
+
+var (
+	getAllFirewallRulesURL = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/firewallrules"
+	createFirewallRuleURL  = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/firewallrules"
+	getFirewallRuleByID    = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/firewallrules/id/{{.ResourceId}}"
+	updateFirewallRuleURL  = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/firewallrules/id/{{.ResourceId}}"
+	deleteFirewallRuleURL  = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/firewallrules/id/{{.ResourceId}}"
+)
+
+const (
+	FirewallRuleStateActive = "Active"
+	FirewallRuleStateFailed = "Failed"
+)
+
+type FirewallRules struct {
+	FirewallRules []FirewallRule `json:"items"`
+	NextPageToken string         `json:"nextPageToken,omitempty"`
+}
+
+type FirewallRule struct {
+	Metadata FirewallRuleMetadata `json:"metadata"`
+	Spec     FirewallRuleSpec     `json:"spec"`
+	Status   FirewallRuleStatus   `json:"status"`
+}
+
+type FirewallRuleMetadata struct {
+	CloudAccountID  string `json:"cloudAccountId"`
+	Name            string `json:"name"`
+	ResourceID      string `json:"resourceId"`
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+// FirewallRuleSpec describes an ingress rule. A rule targets either a vnet or a load balancer VIP.
+type FirewallRuleSpec struct {
+	VNet          string   `json:"vNet,omitempty"`
+	DestinationIP string   `json:"destinationIp,omitempty"`
+	Protocol      string   `json:"protocol"`
+	PortStart     int32    `json:"portStart"`
+	PortEnd       int32    `json:"portEnd"`
+	SourceIPs     []string `json:"sourceips"`
+}
+
+type FirewallRuleStatus struct {
+	State   string `json:"state"`
+	Message string `json:"message"`
+}
+
+type FirewallRuleCreateRequest struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec FirewallRuleSpec `json:"spec"`
+}
+
+type FirewallRuleUpdateRequest struct {
+	Spec FirewallRuleSpec `json:"spec"`
+}
+
+func (client *IDCServicesClient) CreateFirewallRule(ctx context.Context, in *FirewallRuleCreateRequest) (*FirewallRule, error) {
+	rule, err := createResource[FirewallRule](ctx, client, "firewall rule", in.Metadata.Name, createFirewallRuleURL, in)
+	if err != nil {
+		return nil, err
+	}
+	return client.waitForFirewallRuleActive(ctx, rule.Metadata.ResourceID, 1)
+}
+
+func (client *IDCServicesClient) GetFirewallRules(ctx context.Context) (*FirewallRules, error) {
+	items, err := listResources(ctx, client, "firewall rules", getAllFirewallRulesURL, func(retval []byte) ([]FirewallRule, string, error) {
+		page := FirewallRules{}
+		err := json.Unmarshal(retval, &page)
+		return page.FirewallRules, page.NextPageToken, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallRules{FirewallRules: items}, nil
+}
+
+func (client *IDCServicesClient) GetFirewallRuleByID(ctx context.Context, resourceId string) (*FirewallRule, error) {
+	return getResourceByID[FirewallRule](ctx, client, "firewall rule", getFirewallRuleByID, resourceId)
+}
+
+func (client *IDCServicesClient) UpdateFirewallRule(ctx context.Context, resourceId string, in *FirewallRuleUpdateRequest) (*FirewallRule, error) {
+	if err := updateResourceByID(ctx, client, "firewall rule", updateFirewallRuleURL, resourceId, in); err != nil {
+		return nil, err
+	}
+	// the rule status is only reset once the backend starts reprogramming the vnet or VIP, so a
+	// single Active read can still describe the previous ports and sources
+	return client.waitForFirewallRuleActive(ctx, resourceId, 2)
+}
+
+func (client *IDCServicesClient) DeleteFirewallRule(ctx context.Context, resourceId string) error {
+	return deleteResourceByID(ctx, client, "firewall rule", deleteFirewallRuleURL, resourceId)
+}
+
+// waitForFirewallRuleActive waits until the firewall rule is programmed, seeing it active on
+// minTargetReads consecutive reads. A failed rule stops the wait with the message reported by
+// the backend.
+func (client *IDCServicesClient) waitForFirewallRuleActive(ctx context.Context, resourceId string, minTargetReads int) (*FirewallRule, error) {
+	waiter := client.firewallRuleWaiter(resourceId)
+	waiter.Target = []string{FirewallRuleStateActive}
+	waiter.Failure = []string{FirewallRuleStateFailed}
+	waiter.MinTargetReads = minTargetReads
+	return waiter.Wait(ctx)
+}
+
+// WaitForFirewallRuleDeleted waits until the firewall rule is gone after a delete request.
+func (client *IDCServicesClient) WaitForFirewallRuleDeleted(ctx context.Context, resourceId string) error {
+	waiter := client.firewallRuleWaiter(resourceId)
+	waiter.Goal = "deleted"
+	waiter.TargetNotFound = true
+	_, err := waiter.Wait(ctx)
+	return err
+}
+
+func (client *IDCServicesClient) firewallRuleWaiter(resourceId string) *Waiter[FirewallRule] {
+	return &Waiter[FirewallRule]{
+		Noun: "firewall rule",
+		ID:   resourceId,
+		Refresh: func(ctx context.Context) (*FirewallRule, string, error) {
+			rule, err := client.GetFirewallRuleByID(ctx, resourceId)
+			if err != nil {
+				return nil, "", err
+			}
+			return rule, rule.Status.State, nil
+		},
+		Message: func(rule *FirewallRule) string {
+			return rule.Status.Message
+		},
+	}
+}
@@ -5,25 +5,34 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"terraform-provider-intelcloud/pkg/itacservices/common"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	retry "github.com/sethvargo/go-retry"
 )
 
 var (
 	getAllInstancesByAccount   = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/instances"
 	createInstance             = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/instances"
 	getInstanceByResourceId    = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/instances/id/{{.ResourceId}}"
+	getInstanceByName          = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/instances/name/{{.Name}}"
 	deleteInstanceByResourceId = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/instances/id/{{.ResourceId}}"
 
 	getAllVNetsByAccount = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/vnets"
 	createVNetByAccount  = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/vnets"
 )
 
+const (
+	InstancePhaseProvisioning = "Provisioning"
+	InstancePhaseStarting     = "Starting"
+	InstancePhaseReady        = "Ready"
+	InstancePhaseFailed       = "Failed"
+)
+
 type Instances struct {
-	Instances []Instance `json:"items"`
+	Instances     []Instance `json:"items"`
+	NextPageToken string     `json:"nextPageToken,omitempty"`
 }
 
 type Instance struct {
@@ -88,7 +97,8 @@ type InstanceCreateRequest struct {
 }
 
 type VNets struct {
-	Vnets []VNet `json:"items"`
+	Vnets         []VNet `json:"items"`
+	NextPageToken string `json:"nextPageToken,omitempty"`
 }
 
 type VNet struct {
@@ -128,24 +138,18 @@ func (client *IDCServicesClient) GetInstances(ctx context.Context) (*Instances,
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(getAllInstancesByAccount, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to list instances: %w", err)
 	}
 
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
-	tflog.Debug(ctx, "instances read api", map[string]any{"retcode": retcode, "retval": string(retval)})
+	items, err := newPageIterator("instances", parsedURL, client.commonGet, func(retval []byte) ([]Instance, string, error) {
+		page := Instances{}
+		err := json.Unmarshal(retval, &page)
+		return page.Instances, page.NextPageToken, err
+	}).All(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error reading instances")
-	}
-
-	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, err
 	}
-
-	instances := Instances{}
-	if err := json.Unmarshal(retval, &instances); err != nil {
-		return nil, fmt.Errorf("error parsing instances get response, %v", err)
-	}
-	return &instances, nil
+	return &Instances{Instances: items}, nil
 }
 
 func (client *IDCServicesClient) CreateInstance(ctx context.Context, in *InstanceCreateRequest, async bool) (*Instance, error) {
@@ -160,56 +164,74 @@ func (client *IDCServicesClient) CreateInstance(ctx context.Context, in *Instanc
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(createInstance, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to create instance %s: %w", in.Metadata.Name, err)
 	}
 
 	inArgs, err := json.MarshalIndent(in, "", "    ")
 	if err != nil {
-		return nil, fmt.Errorf("error parsing input arguments")
+		return nil, fmt.Errorf("error encoding create request for instance %s: %w", in.Metadata.Name, err)
 	}
 
 	tflog.Debug(ctx, "instance create api request", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
-	retcode, retval, err := common.MakePOSTAPICall(ctx, parsedURL, *client.Apitoken, inArgs)
+	retcode, retval, err := common.MakePOSTAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), inArgs)
 
 	if err != nil {
-		return nil, fmt.Errorf("error reading instance create response")
+		return nil, fmt.Errorf("error creating instance %s: %w", in.Metadata.Name, err)
 	}
 	tflog.Debug(ctx, "instance create api response", map[string]any{"retcode": retcode, "retval": string(retval)})
 
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error creating instance %s: %w", in.Metadata.Name, common.MapHttpError(retcode, retval))
 	}
 
 	instance := &Instance{}
 	if err := json.Unmarshal(retval, instance); err != nil {
-		return nil, fmt.Errorf("error parsing instance response")
+		return nil, fmt.Errorf("error parsing create response for instance %s: %w", in.Metadata.Name, err)
 	}
+	resourceId := instance.Metadata.ResourceId
 
 	if async {
-		instance, err = client.GetInstanceByResourceId(ctx, instance.Metadata.ResourceId)
+		instance, err = client.GetInstanceByResourceId(ctx, resourceId)
 		if err != nil {
-			return instance, fmt.Errorf("error reading instance state")
+			return instance, err
 		}
 	} else {
-		backoffTimer := retry.NewConstant(common.DefaultRetryInterval)
+		waiter := client.instanceWaiter(resourceId)
+		waiter.Pending = []string{"", InstancePhaseProvisioning, InstancePhaseStarting}
+		waiter.Target = []string{InstancePhaseReady}
+		waiter.Failure = []string{InstancePhaseFailed}
+		instance, err = waiter.Wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return instance, nil
+}
+
+// WaitForInstanceDeleted waits until the instance is gone after a delete request.
+func (client *IDCServicesClient) WaitForInstanceDeleted(ctx context.Context, resourceId string) error {
+	waiter := client.instanceWaiter(resourceId)
+	waiter.Goal = "deleted"
+	waiter.TargetNotFound = true
+	_, err := waiter.Wait(ctx)
+	return err
+}
 
-		if err := retry.Do(ctx, backoffTimer, func(_ context.Context) error {
-			instance, err = client.GetInstanceByResourceId(ctx, instance.Metadata.ResourceId)
+func (client *IDCServicesClient) instanceWaiter(resourceId string) *Waiter[Instance] {
+	return &Waiter[Instance]{
+		Noun: "instance",
+		ID:   resourceId,
+		Refresh: func(ctx context.Context) (*Instance, string, error) {
+			instance, err := client.GetInstanceByResourceId(ctx, resourceId)
 			if err != nil {
-				return fmt.Errorf("error reading instance state")
+				return nil, "", err
 			}
-			if instance.Status.Phase == "Ready" {
-				return nil
-			} else if instance.Status.Phase == "Failed" {
-				return fmt.Errorf("instance state failed")
-			} else {
-				return retry.RetryableError(fmt.Errorf("instance state not ready, retry again"))
-			}
-		}); err != nil {
-			return nil, fmt.Errorf("instance state not ready after maximum retries")
-		}
+			return instance, instance.Status.Phase, nil
+		},
+		Message: func(instance *Instance) string {
+			return instance.Status.Message
+		},
 	}
-	return instance, nil
 }
 
 func (client *IDCServicesClient) GetInstanceByResourceId(ctx context.Context, resourceId string) (*Instance, error) {
@@ -226,22 +248,57 @@ func (client *IDCServicesClient) GetInstanceByResourceId(ctx context.Context, re
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(getInstanceByResourceId, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to read instance %s: %w", resourceId, err)
 	}
 
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeGetAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return nil, fmt.Errorf("error reading sshkey by resource id")
+		return nil, fmt.Errorf("error reading instance %s: %w", resourceId, err)
 	}
 
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error reading instance %s: %w", resourceId, common.MapHttpError(retcode, retval))
 	}
 
 	tflog.Debug(ctx, "get instance api", map[string]any{"retcode": retcode})
 	instance := Instance{}
 	if err := json.Unmarshal(retval, &instance); err != nil {
-		return nil, fmt.Errorf("error parsing get instance response")
+		return nil, fmt.Errorf("error parsing instance %s response: %w", resourceId, err)
+	}
+	return &instance, nil
+}
+
+// GetInstanceByName returns the instance with the given name.
+func (client *IDCServicesClient) GetInstanceByName(ctx context.Context, name string) (*Instance, error) {
+	params := struct {
+		Host         string
+		Cloudaccount string
+		Name         string
+	}{
+		Host:         *client.Host,
+		Cloudaccount: *client.Cloudaccount,
+		Name:         url.PathEscape(name),
+	}
+
+	// Parse the template string with the provided data
+	parsedURL, err := client.APIClient.ParseString(getInstanceByName, params)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the url to read instance %s: %w", name, err)
+	}
+
+	retcode, retval, err := client.APIClient.MakeGetAPICall(ctx, parsedURL, client.accessToken(ctx), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error reading instance %s: %w", name, err)
+	}
+
+	if retcode != http.StatusOK {
+		return nil, fmt.Errorf("error reading instance %s: %w", name, common.MapHttpError(retcode, retval))
+	}
+
+	tflog.Debug(ctx, "get instance api", map[string]any{"retcode": retcode})
+	instance := Instance{}
+	if err := json.Unmarshal(retval, &instance); err != nil {
+		return nil, fmt.Errorf("error parsing instance %s response: %w", name, err)
 	}
 	return &instance, nil
 }
@@ -260,16 +317,16 @@ func (client *IDCServicesClient) DeleteInstanceByResourceId(ctx context.Context,
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(deleteInstanceByResourceId, params)
 	if err != nil {
-		return fmt.Errorf("error parsing the url")
+		return fmt.Errorf("error parsing the url to delete instance %s: %w", resourceId, err)
 	}
 
-	retcode, retval, err := common.MakeDeleteAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeDeleteAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return fmt.Errorf("error deleting sshkey by resource id")
+		return fmt.Errorf("error deleting instance %s: %w", resourceId, err)
 	}
 
 	if retcode != http.StatusOK {
-		return common.MapHttpError(retcode, retval)
+		return fmt.Errorf("error deleting instance %s: %w", resourceId, common.MapHttpError(retcode, retval))
 	}
 
 	tflog.Debug(ctx, "instance delete api", map[string]any{"retcode": retcode})
@@ -277,8 +334,7 @@ func (client *IDCServicesClient) DeleteInstanceByResourceId(ctx context.Context,
 	return nil
 }
 
-func (client *IDCServicesClient) CreateVNetIfNotFound(ctx context.Context, region string) (*VNet, error) {
-
+func (client *IDCServicesClient) GetVNets(ctx context.Context) (*VNets, error) {
 	params := struct {
 		Host         string
 		Cloudaccount string
@@ -290,22 +346,42 @@ func (client *IDCServicesClient) CreateVNetIfNotFound(ctx context.Context, regio
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(getAllVNetsByAccount, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to list vnets: %w", err)
 	}
 	tflog.Debug(ctx, "vnets get api request", map[string]any{"url": parsedURL})
 
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	items, err := newPageIterator("vnets", parsedURL, client.commonGet, func(retval []byte) ([]VNet, string, error) {
+		page := VNets{}
+		err := json.Unmarshal(retval, &page)
+		return page.Vnets, page.NextPageToken, err
+	}).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, "vnets get api response", map[string]any{"retval": items})
 
-	if err != nil || retcode != http.StatusOK {
-		tflog.Debug(ctx, "vnet get response", map[string]any{"retcode": retcode, "error": err})
-		return nil, fmt.Errorf("error reading vnets get response")
+	return &VNets{Vnets: items}, nil
+}
+
+// GetVNetByName returns the vnet with the given name, or an error if the account has none.
+func (client *IDCServicesClient) GetVNetByName(ctx context.Context, name string) (*VNet, error) {
+	vnets, err := client.GetVNets(ctx)
+	if err != nil {
+		return nil, err
 	}
+	for i := range vnets.Vnets {
+		if vnets.Vnets[i].Metadata.Name == name {
+			return &vnets.Vnets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("vnet %s: %w", name, common.ErrNotFound)
+}
 
-	vnets := VNets{}
-	if err := json.Unmarshal(retval, &vnets); err != nil {
-		return nil, fmt.Errorf("error parsing instance response")
+func (client *IDCServicesClient) CreateVNetIfNotFound(ctx context.Context, region string) (*VNet, error) {
+	vnets, err := client.GetVNets(ctx)
+	if err != nil {
+		return nil, err
 	}
-	tflog.Debug(ctx, "vnets get api response", map[string]any{"retcode": retcode, "retval": vnets})
 
 	if len(vnets.Vnets) > 0 {
 		tflog.Debug(ctx, "existing vnets found")
@@ -314,7 +390,43 @@ func (client *IDCServicesClient) CreateVNetIfNotFound(ctx context.Context, regio
 
 	tflog.Debug(ctx, "vnets not found, creating a new")
 
-	availabilityZone, vnetName := common.GetAvailabiltyZoneAndVnet(region)
+	regionInfo, err := client.regionInfo(region)
+	if err != nil {
+		return nil, err
+	}
+	availabilityZone := regionInfo.DefaultZone()
+	return client.createVNet(ctx, region, availabilityZone, common.GetDefaultVnetName(availabilityZone))
+}
+
+// CreateVNetInZoneIfNotFound returns the first vnet of the availability zone,
+// creating the zone's default vnet when there is none.
+func (client *IDCServicesClient) CreateVNetInZoneIfNotFound(ctx context.Context, region, availabilityZone string) (*VNet, error) {
+	vnets, err := client.GetVNets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range vnets.Vnets {
+		if vnets.Vnets[i].Spec.AvailabilityZone == availabilityZone {
+			tflog.Debug(ctx, "existing vnet found", map[string]any{"availabilityZone": availabilityZone})
+			return &vnets.Vnets[i], nil
+		}
+	}
+
+	tflog.Debug(ctx, "vnet not found in zone, creating a new", map[string]any{"availabilityZone": availabilityZone})
+
+	return client.createVNet(ctx, region, availabilityZone, common.GetDefaultVnetName(availabilityZone))
+}
+
+func (client *IDCServicesClient) createVNet(ctx context.Context, region, availabilityZone, vnetName string) (*VNet, error) {
+	params := struct {
+		Host         string
+		Cloudaccount string
+	}{
+		Host:         *client.Host,
+		Cloudaccount: *client.Cloudaccount,
+	}
+
 	inArgs := VNetCreateRequest{
 		Metadata: struct {
 			Name         string "json:\"name\""
@@ -336,27 +448,29 @@ func (client *IDCServicesClient) CreateVNetIfNotFound(ctx context.Context, regio
 
 	payload, err := json.MarshalIndent(inArgs, "", "    ")
 	if err != nil {
-		return nil, fmt.Errorf("error parsing input arguments")
+		return nil, fmt.Errorf("error encoding create request for vnet %s: %w", vnetName, err)
 	}
 
 	// Parse the template string with the provided data
-	parsedURL, err = common.ParseString(createVNetByAccount, params)
+	parsedURL, err := common.ParseString(createVNetByAccount, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to create vnet %s: %w", vnetName, err)
 	}
 
-	retcode, retval, err = common.MakePOSTAPICall(ctx, parsedURL, *client.Apitoken, payload)
+	retcode, retval, err := common.MakePOSTAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), payload)
+	if err != nil {
+		return nil, fmt.Errorf("error creating vnet %s: %w", vnetName, err)
+	}
 
-	if err != nil || retcode != http.StatusOK {
-		return nil, fmt.Errorf("error reading vnet create response: %v", err)
+	if retcode != http.StatusOK {
+		return nil, fmt.Errorf("error creating vnet %s: %w", vnetName, common.MapHttpError(retcode, retval))
 	}
 
 	vnet := VNet{}
 	if err := json.Unmarshal(retval, &vnet); err != nil {
-		return nil, fmt.Errorf("error parsing vnet response")
+		return nil, fmt.Errorf("error parsing create response for vnet %s: %w", vnetName, err)
 	}
 	tflog.Debug(ctx, "vnet create api response", map[string]any{"retcode": retcode, "retval": vnet})
 
 	return &vnet, nil
-
 }
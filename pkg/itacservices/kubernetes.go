@@ -5,13 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
-	"time"
 
 	"terraform-provider-intelcloud/pkg/itacservices/common"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	retry "github.com/sethvargo/go-retry"
 )
 
 var (
@@ -23,6 +22,7 @@ var (
 	createK8sNodeGroupURL = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/iks/clusters/{{.ClusterUUID}}/nodegroups"
 	getK8sNodeGroupURL    = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/iks/clusters/{{.ClusterUUID}}/nodegroups/{{.NodeGroupUUID}}"
 	updateNodeGroupURL    = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/iks/clusters/{{.ClusterUUID}}/nodegroups/{{.NodeGroupUUID}}"
+	upgradeNodeGroupURL   = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/iks/clusters/{{.ClusterUUID}}/nodegroups/{{.NodeGroupUUID}}/upgrade"
 
 	getK8sKubeconfigURL  = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/iks/clusters/{{.ClusterUUID}}/kubeconfig"
 	upgradeK8sClusterURL = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/iks/clusters/{{.ClusterUUID}}/upgrade"
@@ -33,10 +33,23 @@ var (
 	getIKSLBURLByID         = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/iks/clusters/{{.ClusterUUID}}/loadbalancers/{{.LbID}}"
 	updateIKSLBURL          = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/iks/clusters/{{.ClusterUUID}}/loadbalancers/{{.LbID}}"
 	deleteIKSLBURLByID      = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/iks/clusters/{{.ClusterUUID}}/loadbalancers/{{.LbID}}"
+
+	getIKSK8sVersionsURL = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/iks/metadata/k8sversions"
+)
+
+const (
+	// DefaultIKSRuntime is the container runtime used for new IKS clusters.
+	DefaultIKSRuntime = "Containerd"
+
+	// IKS reports free-form intermediate states, so IKS waits treat every state other than
+	// these as pending.
+	IKSStateActive = "Active"
+	IKSStateFailed = "Failed"
 )
 
 type IKSClusters struct {
-	Clusters []IKSCluster `json:"clusters"`
+	Clusters      []IKSCluster `json:"clusters"`
+	NextPageToken string       `json:"nextPageToken,omitempty"`
 }
 
 type IKSCluster struct {
@@ -84,6 +97,35 @@ type NodeGroup struct {
 	IMIID                string `json:"imiid"`
 	UserDataURL          string `json:"userdataurl"`
 	Interfaces           []Vnet `json:"vnets"`
+	Nodes                []Node `json:"nodes"`
+	UpgradeAvailable     bool   `json:"upgradeavailable"`
+	UpgradeIMIID         string `json:"upgradeimiid"`
+
+	Autoscaling *NodeGroupAutoscaling `json:"autoscaling,omitempty"`
+	Labels      map[string]string     `json:"labels,omitempty"`
+	Annotations map[string]string     `json:"annotations,omitempty"`
+	Taints      []Taint               `json:"taints,omitempty"`
+}
+
+type Node struct {
+	Name             string `json:"name"`
+	IPAddress        string `json:"ipaddress"`
+	State            string `json:"state"`
+	AvailabilityZone string `json:"availabilityzonename"`
+}
+
+// Taint is a Kubernetes taint applied to every node of a node group.
+type Taint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Effect string `json:"effect"`
+}
+
+// NodeGroupAutoscaling holds the IKS cluster autoscaler settings of a node group.
+type NodeGroupAutoscaling struct {
+	Enabled  bool  `json:"enabled"`
+	MinCount int64 `json:"mincount"`
+	MaxCount int64 `json:"maxcount"`
 }
 
 type SKey struct {
@@ -104,6 +146,11 @@ type IKSNodeGroupCreateRequest struct {
 	SSHKeyNames    []SKey `json:"sshkeyname"`
 	UserDataURL    string `json:"userdataurl"`
 	Vnets          []Vnet `json:"vnets"`
+
+	Autoscaling *NodeGroupAutoscaling `json:"autoscaling,omitempty"`
+	Labels      map[string]string     `json:"labels,omitempty"`
+	Annotations map[string]string     `json:"annotations,omitempty"`
+	Taints      []Taint               `json:"taints,omitempty"`
 }
 
 type Vnet struct {
@@ -111,6 +158,15 @@ type Vnet struct {
 	NetworkInterfaceVnetName string `json:"networkinterfacevnetname"`
 }
 
+type IKSK8sVersions struct {
+	K8sVersions []IKSK8sVersion `json:"k8sversions"`
+}
+
+type IKSK8sVersion struct {
+	K8sVersion  string `json:"k8sversionname"`
+	RuntimeName string `json:"runtimename"`
+}
+
 type IKSCreateRequest struct {
 	Name         string `json:"name"`
 	Count        int64  `json:"count"`
@@ -208,7 +264,8 @@ type IKSLoadBalancerConditionsListenerStatus struct {
 }
 
 type IKSLBsByCluster struct {
-	Items []IKSLoadBalancerItems `json:"items"`
+	Items         []IKSLoadBalancerItems `json:"items"`
+	NextPageToken string                 `json:"nextPageToken,omitempty"`
 }
 
 type IKSLoadbalancerCreateRequest struct {
@@ -219,7 +276,7 @@ type IKSLoadbalancerCreateRequest struct {
 type LoadBalancerSpec struct {
 	Listeners []LoadBalancerListener  `json:"listeners"`
 	Security  IKSLoadBalancerSecurity `json:"security"`
-	Schema    string                  `json:"schema"`
+	Schema    string                  `json:"schema,omitempty"`
 }
 
 type LoadBalancerListener struct {
@@ -273,12 +330,18 @@ type LoadBalancerPool struct {
 }
 
 type IKSLoadBalancerPool struct {
-	Port              int64  `json:"port"`
-	Monitor           string `json:"monitor"`
-	LoadBalancingMode string `json:"loadBalancingMode"`
-	NodeGroupID       string `json:"nodeGroupID"`
+	Port                int64             `json:"port"`
+	Monitor             string            `json:"monitor"`
+	LoadBalancingMode   string            `json:"loadBalancingMode"`
+	NodeGroupID         string            `json:"nodeGroupID,omitempty"`
+	InstanceSelectors   map[string]string `json:"instanceSelectors,omitempty"`
+	InstanceResourceIds []string          `json:"instanceResourceIds,omitempty"`
 }
 
+// IKSNodeGroupNameSelector is the instance selector under which the load balancer
+// API reports the node group targeted by a pool.
+const IKSNodeGroupNameSelector = "nodegroupName"
+
 type LoadBalancerMetadataUpdateIKS struct {
 	CloudAccountId  string            `json:"cloudAccountId"`
 	ResourceId      string            `json:"resourceId"`
@@ -347,10 +410,37 @@ type UpdateNodeGroupRequest struct {
 	ClusterId   string `json:"clusteruuid"`
 	NodeGroupId string `json:"nodegroupuuid"`
 	Count       int64  `json:"count"`
+	SSHKeyNames []SKey `json:"sshkeyname"`
+	UserDataURL string `json:"userdataurl"`
+
+	Autoscaling *NodeGroupAutoscaling `json:"autoscaling,omitempty"`
+	Labels      map[string]string     `json:"labels"`
+	Annotations map[string]string     `json:"annotations"`
+	Taints      []Taint               `json:"taints"`
 }
 
+// UpdateNodeGroupPayload always carries labels, annotations and taints so
+// that removing all of them clears them on the node group. Count is left out
+// while the autoscaler owns the node count.
 type UpdateNodeGroupPayload struct {
-	Count int64 `json:"count"`
+	Count       *int64 `json:"count,omitempty"`
+	SSHKeyNames []SKey `json:"sshkeyname"`
+	UserDataURL string `json:"userdataurl"`
+
+	Autoscaling *NodeGroupAutoscaling `json:"autoscaling,omitempty"`
+	Labels      map[string]string     `json:"labels"`
+	Annotations map[string]string     `json:"annotations"`
+	Taints      []Taint               `json:"taints"`
+}
+
+type UpgradeNodeGroupRequest struct {
+	ClusterId   string `json:"clusteruuid"`
+	NodeGroupId string `json:"nodegroupuuid"`
+	IMIID       string `json:"imiid"`
+}
+
+type UpgradeNodeGroupPayload struct {
+	IMIID string `json:"imiid,omitempty"`
 }
 
 func (client *IDCServicesClient) GetKubernetesClusters(ctx context.Context) (*IKSClusters, *string, error) {
@@ -365,26 +455,51 @@ func (client *IDCServicesClient) GetKubernetesClusters(ctx context.Context) (*IK
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(getAllK8sClustersURL, params)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing the url")
+		return nil, nil, fmt.Errorf("error parsing the url to list iks clusters: %w", err)
 	}
 
-	retcode, retval, err := client.APIClient.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
-	tflog.Debug(ctx, "iks read api", map[string]any{"retcode": retcode, "retval": string(retval)})
+	items, err := newPageIterator("iks clusters", parsedURL, client.apiClientGet, func(retval []byte) ([]IKSCluster, string, error) {
+		page := IKSClusters{}
+		err := json.Unmarshal(retval, &page)
+		return page.Clusters, page.NextPageToken, err
+	}).All(ctx)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error reading iks clusters")
+		return nil, nil, err
 	}
 
-	if retcode != http.StatusOK {
-		return nil, nil, common.MapHttpError(retcode, retval)
+	return &IKSClusters{Clusters: items}, client.Cloudaccount, nil
+}
+
+func (client *IDCServicesClient) GetIKSK8sVersions(ctx context.Context) (*IKSK8sVersions, error) {
+	params := struct {
+		Host         string
+		Cloudaccount string
+	}{
+		Host:         *client.Host,
+		Cloudaccount: *client.Cloudaccount,
+	}
+
+	// Parse the template string with the provided data
+	parsedURL, err := client.APIClient.ParseString(getIKSK8sVersionsURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the url to list iks k8s versions: %w", err)
 	}
 
-	clusters := IKSClusters{}
-	if err := json.Unmarshal(retval, &clusters); err != nil {
-		tflog.Debug(ctx, "iks read api", map[string]any{"err": err})
-		return nil, nil, fmt.Errorf("error parsing iks cluster response")
+	retcode, retval, err := client.APIClient.MakeGetAPICall(ctx, parsedURL, client.accessToken(ctx), nil)
+	tflog.Debug(ctx, "iks k8s versions read api", map[string]any{"retcode": retcode, "retval": string(retval)})
+	if err != nil {
+		return nil, fmt.Errorf("error listing iks k8s versions: %w", err)
 	}
 
-	return &clusters, client.Cloudaccount, nil
+	if retcode != http.StatusOK {
+		return nil, fmt.Errorf("error listing iks k8s versions: %w", common.MapHttpError(retcode, retval))
+	}
+
+	versions := IKSK8sVersions{}
+	if err := json.Unmarshal(retval, &versions); err != nil {
+		return nil, fmt.Errorf("error parsing iks k8s versions response: %w", err)
+	}
+	return &versions, nil
 }
 
 func (client *IDCServicesClient) CreateIKSCluster(ctx context.Context, in *IKSCreateRequest, async bool) (*IKSCluster, *string, error) {
@@ -399,60 +514,73 @@ func (client *IDCServicesClient) CreateIKSCluster(ctx context.Context, in *IKSCr
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(createK8sClusterURL, params)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing the url")
+		return nil, nil, fmt.Errorf("error parsing the url to create iks cluster %s: %w", in.Name, err)
 	}
 
 	inArgs, err := json.MarshalIndent(in, "", "    ")
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing input arguments")
+		return nil, nil, fmt.Errorf("error encoding create request for iks cluster %s: %w", in.Name, err)
 	}
 
 	tflog.Debug(ctx, "iks create api request", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
-	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, *client.Apitoken, inArgs)
+	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, client.accessToken(ctx), inArgs)
 
 	if err != nil {
-		return nil, nil, fmt.Errorf("error reading iks create response")
+		return nil, nil, fmt.Errorf("error creating iks cluster %s: %w", in.Name, err)
 	}
 	tflog.Debug(ctx, "iks create api response", map[string]any{"retcode": retcode, "retval": string(retval)})
 
 	if retcode != http.StatusOK {
-		return nil, nil, common.MapHttpError(retcode, retval)
+		return nil, nil, fmt.Errorf("error creating iks cluster %s: %w", in.Name, common.MapHttpError(retcode, retval))
 	}
 
 	cluster := &IKSCluster{}
 	if err := json.Unmarshal(retval, cluster); err != nil {
-		return nil, nil, fmt.Errorf("error parsing instance response")
+		return nil, nil, fmt.Errorf("error parsing create response for iks cluster %s: %w", in.Name, err)
 	}
+	clusterUUID := cluster.ResourceId
 
 	if async {
-		cluster, _, err = client.GetIKSClusterByClusterUUID(ctx, cluster.ResourceId)
+		cluster, _, err = client.GetIKSClusterByClusterUUID(ctx, clusterUUID)
 		if err != nil {
-			return cluster, nil, fmt.Errorf("error reading iks cluster state")
+			return cluster, nil, err
 		}
 	} else {
-		backoffTimer := retry.NewConstant(5 * time.Second)
-		backoffTimer = retry.WithMaxDuration(3000*time.Second, backoffTimer)
-
-		if err := retry.Do(ctx, backoffTimer, func(_ context.Context) error {
-			cluster, _, err = client.GetIKSClusterByClusterUUID(ctx, cluster.ResourceId)
-			if err != nil {
-				return fmt.Errorf("error reading instance state")
-			}
-			if cluster.ClusterState == "Active" {
-				return nil
-			} else if cluster.ClusterState == "Failed" {
-				return fmt.Errorf("instance state failed")
-			} else {
-				return retry.RetryableError(fmt.Errorf("iks cluster state not ready, retry again"))
-			}
-		}); err != nil {
-			return nil, nil, fmt.Errorf("iks cluster state not ready after maximum retries")
+		waiter := client.iksClusterWaiter(clusterUUID)
+		waiter.Target = []string{IKSStateActive}
+		waiter.Failure = []string{IKSStateFailed}
+		cluster, err = waiter.Wait(ctx)
+		if err != nil {
+			return nil, nil, err
 		}
 	}
 
 	return cluster, client.Cloudaccount, nil
 }
 
+// WaitForIKSClusterDeleted waits until the IKS cluster is gone after a delete request.
+func (client *IDCServicesClient) WaitForIKSClusterDeleted(ctx context.Context, clusterUUID string) error {
+	waiter := client.iksClusterWaiter(clusterUUID)
+	waiter.Goal = "deleted"
+	waiter.TargetNotFound = true
+	_, err := waiter.Wait(ctx)
+	return err
+}
+
+func (client *IDCServicesClient) iksClusterWaiter(clusterUUID string) *Waiter[IKSCluster] {
+	return &Waiter[IKSCluster]{
+		Noun: "iks cluster",
+		ID:   clusterUUID,
+		Refresh: func(ctx context.Context) (*IKSCluster, string, error) {
+			cluster, _, err := client.GetIKSClusterByClusterUUID(ctx, clusterUUID)
+			if err != nil {
+				return nil, "", err
+			}
+			return cluster, cluster.ClusterState, nil
+		},
+	}
+}
+
 func (client *IDCServicesClient) GetIKSClusterByClusterUUID(ctx context.Context, clusterUUID string) (*IKSCluster, *string, error) {
 	tflog.Info(ctx, "RK=>get iks cluster by uuid", map[string]any{"clusterUUID": clusterUUID})
 	params := struct {
@@ -468,22 +596,22 @@ func (client *IDCServicesClient) GetIKSClusterByClusterUUID(ctx context.Context,
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(getIksClusterByClusterUUID, params)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing the url")
+		return nil, nil, fmt.Errorf("error parsing the url to read iks cluster %s: %w", clusterUUID, err)
 	}
 
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeGetAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error reading sshkey by resource id")
+		return nil, nil, fmt.Errorf("error reading iks cluster %s: %w", clusterUUID, err)
 	}
 	tflog.Debug(ctx, "iks get cluster by UUID api response", map[string]any{"retcode": retcode, "retval": string(retval)})
 
 	if retcode != http.StatusOK {
-		return nil, nil, common.MapHttpError(retcode, retval)
+		return nil, nil, fmt.Errorf("error reading iks cluster %s: %w", clusterUUID, common.MapHttpError(retcode, retval))
 	}
 
 	cluster := IKSCluster{}
 	if err := json.Unmarshal(retval, &cluster); err != nil {
-		return nil, nil, fmt.Errorf("error parsing iks cluster get response")
+		return nil, nil, fmt.Errorf("error parsing iks cluster %s response: %w", clusterUUID, err)
 	}
 	return &cluster, client.Cloudaccount, nil
 }
@@ -502,17 +630,17 @@ func (client *IDCServicesClient) DeleteIKSCluster(ctx context.Context, clusterUU
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(deleteIksCluster, params)
 	if err != nil {
-		return fmt.Errorf("error parsing the url")
+		return fmt.Errorf("error parsing the url to delete iks cluster %s: %w", clusterUUID, err)
 	}
 
 	tflog.Debug(ctx, "iks cluster delete api", map[string]any{"parsedurl": parsedURL})
-	retcode, retval, err := common.MakeDeleteAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeDeleteAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return fmt.Errorf("error deleting sshkey by resource id")
+		return fmt.Errorf("error deleting iks cluster %s: %w", clusterUUID, err)
 	}
 
 	if retcode != http.StatusOK {
-		return common.MapHttpError(retcode, retval)
+		return fmt.Errorf("error deleting iks cluster %s: %w", clusterUUID, common.MapHttpError(retcode, retval))
 	}
 
 	tflog.Debug(ctx, "iks cluster delete api", map[string]any{"retcode": retcode})
@@ -534,51 +662,65 @@ func (client *IDCServicesClient) CreateIKSNodeGroup(ctx context.Context, in *IKS
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(createK8sNodeGroupURL, params)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing the url")
+		return nil, nil, fmt.Errorf("error parsing the url to create iks node group %s: %w", in.Name, err)
 	}
 
 	inArgs, err := json.MarshalIndent(in, "", "    ")
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing input arguments")
+		return nil, nil, fmt.Errorf("error encoding create request for iks node group %s: %w", in.Name, err)
 	}
 
 	tflog.Debug(ctx, "iks node group create api request", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
-	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, *client.Apitoken, inArgs)
+	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, client.accessToken(ctx), inArgs)
 
 	if err != nil {
-		return nil, nil, fmt.Errorf("error reading iks node group create response")
+		return nil, nil, fmt.Errorf("error creating iks node group %s in cluster %s: %w", in.Name, clusterUUID, err)
 	}
 	tflog.Debug(ctx, "iks node group create api response", map[string]any{"retcode": retcode, "retval": string(retval)})
 
 	if retcode != http.StatusOK {
-		return nil, nil, common.MapHttpError(retcode, retval)
+		return nil, nil, fmt.Errorf("error creating iks node group %s in cluster %s: %w", in.Name, clusterUUID, common.MapHttpError(retcode, retval))
 	}
 
 	ng := &NodeGroup{}
 	if err := json.Unmarshal(retval, ng); err != nil {
-		return nil, nil, fmt.Errorf("error parsing node group response")
+		return nil, nil, fmt.Errorf("error parsing create response for iks node group %s: %w", in.Name, err)
 	}
+	nodeGroupID := ng.ID
 
-	backoffTimer := retry.NewConstant(common.DefaultRetryInterval)
-
-	if err := retry.Do(ctx, backoffTimer, func(_ context.Context) error {
-		ng, err = client.GetIKSNodeGroupByID(ctx, clusterUUID, ng.ID)
-		if err != nil {
-			return fmt.Errorf("error reading node group state")
-		}
-		tflog.Debug(ctx, "iks node group create api response", map[string]any{"nodegroupuuid": ng.ID, "state": ng.State})
-		if ng.State == "Active" {
-			return nil
-		} else if ng.State == "Failed" {
-			return fmt.Errorf("node group state failed")
-		}
-		return retry.RetryableError(fmt.Errorf("iks node group state not ready, retry again"))
-	}); err != nil {
-		return nil, nil, fmt.Errorf("iks node group state not ready after maximum retries")
+	waiter := client.iksNodeGroupWaiter(clusterUUID, nodeGroupID)
+	waiter.Target = []string{IKSStateActive}
+	waiter.Failure = []string{IKSStateFailed}
+	ng, err = waiter.Wait(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
 	return ng, client.Cloudaccount, nil
 }
 
+// WaitForIKSNodeGroupDeleted waits until the node group is gone after a delete request.
+func (client *IDCServicesClient) WaitForIKSNodeGroupDeleted(ctx context.Context, clusterUUID, ngId string) error {
+	waiter := client.iksNodeGroupWaiter(clusterUUID, ngId)
+	waiter.Goal = "deleted"
+	waiter.TargetNotFound = true
+	_, err := waiter.Wait(ctx)
+	return err
+}
+
+func (client *IDCServicesClient) iksNodeGroupWaiter(clusterUUID, ngId string) *Waiter[NodeGroup] {
+	return &Waiter[NodeGroup]{
+		Noun: "iks node group",
+		ID:   ngId,
+		Refresh: func(ctx context.Context) (*NodeGroup, string, error) {
+			ng, err := client.GetIKSNodeGroupByID(ctx, clusterUUID, ngId)
+			if err != nil {
+				return nil, "", err
+			}
+			return ng, ng.State, nil
+		},
+	}
+}
+
 func (client *IDCServicesClient) GetIKSNodeGroupByID(ctx context.Context, clusterId, ngId string) (*NodeGroup, error) {
 	params := struct {
 		Host          string
@@ -595,22 +737,22 @@ func (client *IDCServicesClient) GetIKSNodeGroupByID(ctx context.Context, cluste
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(getK8sNodeGroupURL, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to read iks node group %s: %w", ngId, err)
 	}
 
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeGetAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return nil, fmt.Errorf("error reading node group resource by id")
+		return nil, fmt.Errorf("error reading iks node group %s: %w", ngId, err)
 	}
 	tflog.Debug(ctx, "iks node group read response", map[string]any{"retcode": retcode, "retval": string(retval)})
 
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error reading iks node group %s: %w", ngId, common.MapHttpError(retcode, retval))
 	}
 
 	nodeGroup := NodeGroup{}
 	if err := json.Unmarshal(retval, &nodeGroup); err != nil {
-		return nil, fmt.Errorf("error parsing iks cluster get response")
+		return nil, fmt.Errorf("error parsing iks node group %s response: %w", ngId, err)
 	}
 	return &nodeGroup, nil
 }
@@ -629,55 +771,53 @@ func (client *IDCServicesClient) CreateIKSStorage(ctx context.Context, in *IKSSt
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(createK8sFileStorageURL, params)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing the url")
+		return nil, nil, fmt.Errorf("error parsing the url to create storage for iks cluster %s: %w", clusterUUID, err)
 	}
 
 	inArgs, err := json.MarshalIndent(in, "", "    ")
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing input arguments")
+		return nil, nil, fmt.Errorf("error encoding storage request for iks cluster %s: %w", clusterUUID, err)
 	}
 
 	tflog.Debug(ctx, "iks file storage create api request", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
-	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, *client.Apitoken, inArgs)
+	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, client.accessToken(ctx), inArgs)
 
 	if err != nil {
-		return nil, nil, fmt.Errorf("error reading iks file storage create response")
+		return nil, nil, fmt.Errorf("error creating storage for iks cluster %s: %w", clusterUUID, err)
 	}
 	tflog.Debug(ctx, "iks file storage create api response", map[string]any{"retcode": retcode, "retval": string(retval)})
 
 	if retcode != http.StatusOK {
-		return nil, nil, common.MapHttpError(retcode, retval)
+		return nil, nil, fmt.Errorf("error creating storage for iks cluster %s: %w", clusterUUID, common.MapHttpError(retcode, retval))
 	}
 
 	storage := &K8sStorage{}
 	if err := json.Unmarshal(retval, storage); err != nil {
-		return nil, nil, fmt.Errorf("error parsing node group response")
+		return nil, nil, fmt.Errorf("error parsing storage response for iks cluster %s: %w", clusterUUID, err)
 	}
 
-	backoffTimer := retry.NewConstant(common.DefaultRetryInterval)
-
-	if err := retry.Do(ctx, backoffTimer, func(_ context.Context) error {
-		iksCluster, _, err := client.GetIKSClusterByClusterUUID(ctx, clusterUUID)
-		if err != nil {
-			return fmt.Errorf("error reading node group state")
-		}
-		for _, v := range iksCluster.Storages {
-			if strings.EqualFold(v.Size, storage.Size) {
-				if v.State == "Active" {
-					storage.Provider = v.Provider
-					storage.State = v.State
-					storage.Size = v.Size
-					return nil
-				} else if v.State == "Failed" {
-					return fmt.Errorf("file storage state failed")
+	waiter := &Waiter[K8sStorage]{
+		Noun: "storage of iks cluster",
+		ID:   clusterUUID,
+		Refresh: func(ctx context.Context) (*K8sStorage, string, error) {
+			iksCluster, _, err := client.GetIKSClusterByClusterUUID(ctx, clusterUUID)
+			if err != nil {
+				return nil, "", err
+			}
+			for i := range iksCluster.Storages {
+				if strings.EqualFold(iksCluster.Storages[i].Size, storage.Size) {
+					return &iksCluster.Storages[i], iksCluster.Storages[i].State, nil
 				}
-			} else {
-				return retry.RetryableError(fmt.Errorf("iks file storage state not ready, retry again"))
 			}
-		}
-		return retry.RetryableError(fmt.Errorf("iks file storage state not ready, retry again"))
-	}); err != nil {
-		return nil, nil, fmt.Errorf("iks node group state not ready after maximum retries")
+			// the storage is not listed until the cluster starts provisioning it
+			return storage, "", nil
+		},
+		Target:  []string{IKSStateActive},
+		Failure: []string{IKSStateFailed},
+	}
+	storage, err = waiter.Wait(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return storage, client.Cloudaccount, nil
@@ -697,50 +837,80 @@ func (client *IDCServicesClient) CreateIKSLoadBalancer(ctx context.Context, in *
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(createIKSLBURL, params)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing the url")
+		return nil, nil, fmt.Errorf("error parsing the url to create iks load balancer %s: %w", in.Metadata.Name, err)
 	}
 
 	inArgs, err := json.MarshalIndent(in, "", "    ")
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing input arguments")
+		return nil, nil, fmt.Errorf("error encoding create request for iks load balancer %s: %w", in.Metadata.Name, err)
 	}
 
 	tflog.Debug(ctx, "iks load balancer create api request", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
-	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, *client.Apitoken, inArgs)
+	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, client.accessToken(ctx), inArgs)
 
 	if err != nil {
-		return nil, nil, fmt.Errorf("error reading iks load balancer create response")
+		return nil, nil, fmt.Errorf("error creating iks load balancer %s in cluster %s: %w", in.Metadata.Name, clusterUUID, err)
 	}
 	tflog.Debug(ctx, "iks load balancer create api response", map[string]any{"retcode": retcode, "retval": string(retval)})
 
 	if retcode != http.StatusOK {
-		return nil, nil, common.MapHttpError(retcode, retval)
+		return nil, nil, fmt.Errorf("error creating iks load balancer %s in cluster %s: %w", in.Metadata.Name, clusterUUID, common.MapHttpError(retcode, retval))
 	}
 
 	iksLB := &IKSLoadBalancerItems{}
 	if err := json.Unmarshal(retval, iksLB); err != nil {
-		return nil, nil, fmt.Errorf("error parsing load balancer response: %v", err)
+		return nil, nil, fmt.Errorf("error parsing create response for iks load balancer %s: %w", in.Metadata.Name, err)
 	}
+	lbId := iksLB.Metadata.ResourceID
 
-	backoffTimer := retry.NewConstant(common.DefaultRetryInterval)
-
-	if err := retry.Do(ctx, backoffTimer, func(_ context.Context) error {
-		iksLB, err = client.GetIKSLoadBalancerByID(ctx, clusterUUID, iksLB.Metadata.ResourceID)
-		if err != nil {
-			return fmt.Errorf("error reading node group state")
-		}
-		if iksLB.Status.State == "Active" {
-			return nil
-		} else {
-			return retry.RetryableError(fmt.Errorf("iks load balancer state not ready, retry again"))
-		}
-	}); err != nil {
-		return nil, nil, fmt.Errorf("iks node group state not ready after maximum retries")
+	iksLB, err = client.waitForIKSLoadBalancerActive(ctx, clusterUUID, lbId, 1)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return iksLB, client.Cloudaccount, nil
 }
 
+// waitForIKSLoadBalancerActive waits until the IKS load balancer is active, seeing it active on
+// minTargetReads consecutive reads. A failed load balancer or listener stops the wait with the
+// message reported by the backend.
+func (client *IDCServicesClient) waitForIKSLoadBalancerActive(ctx context.Context, clusterUUID, lbId string, minTargetReads int) (*IKSLoadBalancerItems, error) {
+	waiter := client.iksLoadBalancerWaiter(clusterUUID, lbId)
+	waiter.Target = []string{LoadBalancerStateActive}
+	waiter.Failure = []string{LoadBalancerStateFailed}
+	waiter.Check = func(lb *IKSLoadBalancerItems) error {
+		return loadBalancerStatusError(&lb.Status)
+	}
+	waiter.MinTargetReads = minTargetReads
+	return waiter.Wait(ctx)
+}
+
+// WaitForIKSLoadBalancerDeleted waits until the IKS load balancer is gone after a delete request.
+func (client *IDCServicesClient) WaitForIKSLoadBalancerDeleted(ctx context.Context, clusterUUID, lbId string) error {
+	waiter := client.iksLoadBalancerWaiter(clusterUUID, lbId)
+	waiter.Goal = "deleted"
+	waiter.TargetNotFound = true
+	_, err := waiter.Wait(ctx)
+	return err
+}
+
+func (client *IDCServicesClient) iksLoadBalancerWaiter(clusterUUID, lbId string) *Waiter[IKSLoadBalancerItems] {
+	return &Waiter[IKSLoadBalancerItems]{
+		Noun: "iks load balancer",
+		ID:   lbId,
+		Refresh: func(ctx context.Context) (*IKSLoadBalancerItems, string, error) {
+			lb, err := client.GetIKSLoadBalancerByID(ctx, clusterUUID, lbId)
+			if err != nil {
+				return nil, "", err
+			}
+			return lb, lb.Status.State, nil
+		},
+		Message: func(lb *IKSLoadBalancerItems) string {
+			return lb.Status.Message
+		},
+	}
+}
+
 func (client *IDCServicesClient) GetIKSLoadBalancerByID(ctx context.Context, clusterUUID, lbId string) (*IKSLoadBalancerItems, error) {
 	params := struct {
 		Host         string
@@ -757,27 +927,42 @@ func (client *IDCServicesClient) GetIKSLoadBalancerByID(ctx context.Context, clu
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(getIKSLBURLByID, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to read iks load balancer %s: %w", lbId, err)
 	}
 
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeGetAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return nil, fmt.Errorf("error reading load balancer resource by id")
+		return nil, fmt.Errorf("error reading iks load balancer %s: %w", lbId, err)
 	}
 	tflog.Debug(ctx, "iks load balancer by ID read response", map[string]any{"retcode": retcode, "retval": string(retval)})
 
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error reading iks load balancer %s: %w", lbId, common.MapHttpError(retcode, retval))
 	}
 
 	iksLB := IKSLoadBalancerItems{}
 	if err := json.Unmarshal(retval, &iksLB); err != nil {
-		return nil, fmt.Errorf("error parsing iks load balancer get response")
+		return nil, fmt.Errorf("error parsing iks load balancer %s response: %w", lbId, err)
 	}
 	return &iksLB, nil
 }
 
 func (client *IDCServicesClient) GetIKSLoadBalancerByClusterUUID(ctx context.Context, clusterUUID string) (*IKSLBsByCluster, error) {
+	it, err := client.ListIKSLoadBalancersByCluster(clusterUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := it.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &IKSLBsByCluster{Items: items}, nil
+}
+
+// ListIKSLoadBalancersByCluster returns an iterator over the load balancers of a cluster,
+// for callers that can stop before reading every page.
+func (client *IDCServicesClient) ListIKSLoadBalancersByCluster(clusterUUID string) (*PageIterator[IKSLoadBalancerItems], error) {
 	params := struct {
 		Host         string
 		Cloudaccount string
@@ -791,24 +976,14 @@ func (client *IDCServicesClient) GetIKSLoadBalancerByClusterUUID(ctx context.Con
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(getIKSLBURLByCluster, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
-	}
-
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error reading load balancer resource by cluster")
-	}
-	tflog.Debug(ctx, "iks load balancer by Cluster ID read response", map[string]any{"retcode": retcode, "retval": string(retval)})
-
-	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error parsing the url to list load balancers of iks cluster %s: %w", clusterUUID, err)
 	}
 
-	resp := IKSLBsByCluster{}
-	if err := json.Unmarshal(retval, &resp); err != nil {
-		return nil, fmt.Errorf("error parsing iks load balancer get response: %v", err)
-	}
-	return &resp, nil
+	return newPageIterator("iks load balancers", parsedURL, client.commonGet, func(retval []byte) ([]IKSLoadBalancerItems, string, error) {
+		page := IKSLBsByCluster{}
+		err := json.Unmarshal(retval, &page)
+		return page.Items, page.NextPageToken, err
+	}), nil
 }
 
 func (client *IDCServicesClient) DeleteIKSNodeGroup(ctx context.Context, clusterId, ngId string) error {
@@ -827,17 +1002,17 @@ func (client *IDCServicesClient) DeleteIKSNodeGroup(ctx context.Context, cluster
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(getK8sNodeGroupURL, params)
 	if err != nil {
-		return fmt.Errorf("error parsing the url")
+		return fmt.Errorf("error parsing the url to delete iks node group %s: %w", ngId, err)
 	}
 
 	tflog.Debug(ctx, "iks node group delete api", map[string]any{"parsedurl": parsedURL})
-	retcode, retval, err := common.MakeDeleteAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeDeleteAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return fmt.Errorf("error deleting iks node group by resource id")
+		return fmt.Errorf("error deleting iks node group %s: %w", ngId, err)
 	}
 	tflog.Debug(ctx, "iks node group delete api", map[string]any{"retcode": retcode})
 	if retcode != http.StatusOK {
-		return common.MapHttpError(retcode, retval)
+		return fmt.Errorf("error deleting iks node group %s: %w", ngId, common.MapHttpError(retcode, retval))
 	}
 
 	return nil
@@ -857,21 +1032,21 @@ func (client *IDCServicesClient) GetClusterKubeconfig(ctx context.Context, clust
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(getK8sKubeconfigURL, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to read kubeconfig of iks cluster %s: %w", clusterId, err)
 	}
 
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeGetAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return nil, fmt.Errorf("error calling get kubeconfig api")
+		return nil, fmt.Errorf("error reading kubeconfig of iks cluster %s: %w", clusterId, err)
 	}
 	tflog.Debug(ctx, "iks get kubeconfig", map[string]any{"retcode": retcode})
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error reading kubeconfig of iks cluster %s: %w", clusterId, common.MapHttpError(retcode, retval))
 	}
 
 	resp := KubeconfigResponse{}
 	if err := json.Unmarshal(retval, &resp); err != nil {
-		return nil, fmt.Errorf("error parsing iks kubeconfig get response")
+		return nil, fmt.Errorf("error parsing kubeconfig response of iks cluster %s: %w", clusterId, err)
 	}
 
 	return &resp.Config, nil
@@ -894,45 +1069,37 @@ func (client *IDCServicesClient) UpgradeCluster(ctx context.Context, in *Upgrade
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(upgradeK8sClusterURL, params)
 	if err != nil {
-		return fmt.Errorf("error parsing the url")
+		return fmt.Errorf("error parsing the url to upgrade iks cluster %s: %w", in.ClusterId, err)
 	}
 
 	inArgs, err := json.MarshalIndent(inArg, "", "    ")
 	if err != nil {
-		return fmt.Errorf("error parsing input arguments")
+		return fmt.Errorf("error encoding upgrade request for iks cluster %s: %w", in.ClusterId, err)
 	}
 
-	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, *client.Apitoken, inArgs)
+	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, client.accessToken(ctx), inArgs)
 	if err != nil {
-		return fmt.Errorf("error calling upgrade cluster api")
+		return fmt.Errorf("error upgrading iks cluster %s: %w", in.ClusterId, err)
 	}
 	if retcode != http.StatusOK {
-		return common.MapHttpError(retcode, retval)
+		return fmt.Errorf("error upgrading iks cluster %s: %w", in.ClusterId, common.MapHttpError(retcode, retval))
 	}
 	tflog.Debug(ctx, "iks upgrade cluster", map[string]any{"retcode": retcode, "retval": retval})
 
 	cluster := &IKSCluster{}
 	if err := json.Unmarshal(retval, cluster); err != nil {
-		return fmt.Errorf("error parsing instance response")
+		return fmt.Errorf("error parsing upgrade response for iks cluster %s: %w", in.ClusterId, err)
 	}
 
-	backoffTimer := retry.NewConstant(5 * time.Second)
-	backoffTimer = retry.WithMaxDuration(1800*time.Second, backoffTimer)
-
-	if err := retry.Do(ctx, backoffTimer, func(_ context.Context) error {
-		cluster, _, err = client.GetIKSClusterByClusterUUID(ctx, in.ClusterId)
-		if err != nil {
-			return fmt.Errorf("error reading instance state after upgrade")
-		}
-		if cluster.ClusterState == "Active" {
-			return nil
-		} else if cluster.ClusterState == "Failed" {
-			return fmt.Errorf("instance state failed")
-		} else {
-			return retry.RetryableError(fmt.Errorf("iks cluster state not ready, retry again"))
-		}
-	}); err != nil {
-		return fmt.Errorf("iks cluster state not ready after maximum retries")
+	waiter := client.iksClusterWaiter(in.ClusterId)
+	waiter.Goal = "upgraded to " + in.K8sVersion
+	waiter.Target = []string{IKSStateActive}
+	waiter.Failure = []string{IKSStateFailed}
+	waiter.Done = func(cluster *IKSCluster) bool {
+		return cluster.K8sVersion == in.K8sVersion
+	}
+	if _, err := waiter.Wait(ctx); err != nil {
+		return err
 	}
 
 	return nil
@@ -952,49 +1119,154 @@ func (client *IDCServicesClient) UpdateNodeGroup(ctx context.Context, in *Update
 	}
 
 	inArg := UpdateNodeGroupPayload{
-		Count: in.Count,
+		SSHKeyNames: in.SSHKeyNames,
+		UserDataURL: in.UserDataURL,
+		Autoscaling: in.Autoscaling,
+		Labels:      in.Labels,
+		Annotations: in.Annotations,
+		Taints:      in.Taints,
+	}
+	if in.Autoscaling == nil || !in.Autoscaling.Enabled {
+		inArg.Count = &in.Count
+	}
+	if inArg.Labels == nil {
+		inArg.Labels = map[string]string{}
+	}
+	if inArg.Annotations == nil {
+		inArg.Annotations = map[string]string{}
+	}
+	if inArg.Taints == nil {
+		inArg.Taints = []Taint{}
 	}
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(updateNodeGroupURL, params)
 	if err != nil {
-		return fmt.Errorf("error parsing the url: %v", err)
+		return fmt.Errorf("error parsing the url to update iks node group %s: %w", in.NodeGroupId, err)
 	}
 
 	inArgs, err := json.MarshalIndent(inArg, "", "    ")
 	if err != nil {
-		return fmt.Errorf("error parsing input arguments")
+		return fmt.Errorf("error encoding update request for iks node group %s: %w", in.NodeGroupId, err)
 	}
 
-	retcode, retval, err := client.APIClient.MakePutAPICall(ctx, parsedURL, *client.Apitoken, inArgs)
+	retcode, retval, err := client.APIClient.MakePutAPICall(ctx, parsedURL, client.accessToken(ctx), inArgs)
 	if err != nil {
-		return fmt.Errorf("error calling upgrade cluster api")
+		return fmt.Errorf("error updating iks node group %s: %w", in.NodeGroupId, err)
 	}
 	if retcode != http.StatusOK {
-		return common.MapHttpError(retcode, retval)
+		return fmt.Errorf("error updating iks node group %s: %w", in.NodeGroupId, common.MapHttpError(retcode, retval))
 	}
 	tflog.Debug(ctx, "iks update nodegroup", map[string]any{"retcode": retcode, "retval": retval})
 
 	nodeGroup := &NodeGroup{}
 	if err := json.Unmarshal(retval, nodeGroup); err != nil {
-		return fmt.Errorf("error parsing instance response")
+		return fmt.Errorf("error parsing update response for iks node group %s: %w", in.NodeGroupId, err)
+	}
+
+	waiter := client.iksNodeGroupWaiter(in.ClusterId, in.NodeGroupId)
+	waiter.Goal = "updated"
+	waiter.Target = []string{IKSStateActive}
+	waiter.Failure = []string{IKSStateFailed}
+	waiter.Done = func(ng *NodeGroup) bool {
+		return nodeGroupUpdated(ng, &inArg)
+	}
+	if _, err := waiter.Wait(ctx); err != nil {
+		return err
 	}
 
-	backoffTimer := retry.NewConstant(5 * time.Second)
+	return nil
+}
 
-	if err := retry.Do(ctx, backoffTimer, func(_ context.Context) error {
-		nodeGroup, err = client.GetIKSNodeGroupByID(ctx, in.ClusterId, in.NodeGroupId)
-		if err != nil {
-			return fmt.Errorf("error reading nodegroup state after update")
+// nodeGroupUpdated reports whether the node group shows the update: the requested node count
+// is reached, unless the autoscaler owns it, and the labels, annotations and taints sent are
+// set. Labels, annotations and taints the service adds on its own are not compared.
+func nodeGroupUpdated(ng *NodeGroup, in *UpdateNodeGroupPayload) bool {
+	autoscaled := in.Autoscaling != nil && in.Autoscaling.Enabled
+	if in.Count != nil && !autoscaled && (ng.Count != *in.Count || len(ng.Nodes) != int(*in.Count)) {
+		return false
+	}
+	for _, t := range in.Taints {
+		if !slices.Contains(ng.Taints, t) {
+			return false
+		}
+	}
+	return containsAll(ng.Labels, in.Labels) && containsAll(ng.Annotations, in.Annotations)
+}
+
+// containsAll reports whether m has every key of sub with the same value.
+func containsAll(m, sub map[string]string) bool {
+	for k, v := range sub {
+		if got, ok := m[k]; !ok || got != v {
+			return false
 		}
-		if nodeGroup.State == "Active" {
-			return nil
-		} else if nodeGroup.State == "Failed" {
-			return fmt.Errorf("nodegroup state failed")
-		} else {
-			return retry.RetryableError(fmt.Errorf("iks nodegroup state not ready, retry again"))
+	}
+	return true
+}
+
+func (client *IDCServicesClient) UpgradeNodeGroup(ctx context.Context, in *UpgradeNodeGroupRequest) error {
+	params := struct {
+		Host          string
+		Cloudaccount  string
+		ClusterUUID   string
+		NodeGroupUUID string
+	}{
+		Host:          *client.Host,
+		Cloudaccount:  *client.Cloudaccount,
+		ClusterUUID:   in.ClusterId,
+		NodeGroupUUID: in.NodeGroupId,
+	}
+
+	nodeGroup, err := client.GetIKSNodeGroupByID(ctx, in.ClusterId, in.NodeGroupId)
+	if err != nil {
+		return err
+	}
+	if !nodeGroup.UpgradeAvailable {
+		return fmt.Errorf("no image upgrade available for nodegroup %s, current imiid %s", in.NodeGroupId, nodeGroup.IMIID)
+	}
+	if in.IMIID != "" && nodeGroup.UpgradeIMIID != "" && in.IMIID != nodeGroup.UpgradeIMIID {
+		return fmt.Errorf("imiid %s is not available for nodegroup %s, available upgrade is %s", in.IMIID, in.NodeGroupId, nodeGroup.UpgradeIMIID)
+	}
+
+	inArg := UpgradeNodeGroupPayload{
+		IMIID: in.IMIID,
+	}
+	// Parse the template string with the provided data
+	parsedURL, err := client.APIClient.ParseString(upgradeNodeGroupURL, params)
+	if err != nil {
+		return fmt.Errorf("error parsing the url to upgrade iks node group %s: %w", in.NodeGroupId, err)
+	}
+
+	inArgs, err := json.MarshalIndent(inArg, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error encoding upgrade request for iks node group %s: %w", in.NodeGroupId, err)
+	}
+
+	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, client.accessToken(ctx), inArgs)
+	if err != nil {
+		return fmt.Errorf("error upgrading iks node group %s: %w", in.NodeGroupId, err)
+	}
+	if retcode != http.StatusOK {
+		return fmt.Errorf("error upgrading iks node group %s: %w", in.NodeGroupId, common.MapHttpError(retcode, retval))
+	}
+	tflog.Debug(ctx, "iks upgrade nodegroup", map[string]any{"retcode": retcode, "retval": string(retval)})
+
+	// without an imiid the upgrade goes to the image the node group offered
+	targetIMIID := in.IMIID
+	if targetIMIID == "" {
+		targetIMIID = nodeGroup.UpgradeIMIID
+	}
+	waiter := client.iksNodeGroupWaiter(in.ClusterId, in.NodeGroupId)
+	waiter.Goal = "upgraded"
+	waiter.Target = []string{IKSStateActive}
+	waiter.Failure = []string{IKSStateFailed}
+	waiter.Done = func(ng *NodeGroup) bool {
+		if targetIMIID == "" {
+			return !ng.UpgradeAvailable
 		}
-	}); err != nil {
-		return fmt.Errorf("iks nodegroup state not ready after maximum retries")
+		return ng.IMIID == targetIMIID
+	}
+	if _, err := waiter.Wait(ctx); err != nil {
+		return err
 	}
 
 	return nil
@@ -1016,45 +1288,33 @@ func (client *IDCServicesClient) UpdateIKSLoadBalancer(ctx context.Context, in *
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(updateIKSLBURL, params)
 	if err != nil {
-		return fmt.Errorf("error parsing the url")
+		return fmt.Errorf("error parsing the url to update iks load balancer %s: %w", lbId, err)
 	}
 
 	inArgs, err := json.MarshalIndent(in, "", "    ")
 	if err != nil {
-		return fmt.Errorf("error parsing input arguments")
+		return fmt.Errorf("error encoding update request for iks load balancer %s: %w", lbId, err)
 	}
 
 	tflog.Debug(ctx, "iks load balancer uddate api request", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
-	retcode, retval, err := client.APIClient.MakePutAPICall(ctx, parsedURL, *client.Apitoken, inArgs)
+	retcode, retval, err := client.APIClient.MakePutAPICall(ctx, parsedURL, client.accessToken(ctx), inArgs)
 
 	if err != nil {
-		return fmt.Errorf("error reading iks load balancer update response")
+		return fmt.Errorf("error updating iks load balancer %s: %w", lbId, err)
 	}
 	tflog.Debug(ctx, "iks load balancer update api response", map[string]any{"retcode": retcode, "retval": string(retval)})
 
 	if retcode != http.StatusOK {
-		return common.MapHttpError(retcode, retval)
+		return fmt.Errorf("error updating iks load balancer %s: %w", lbId, common.MapHttpError(retcode, retval))
 	}
 
 	iksLB := &IKSLoadBalancerItems{}
 	if err := json.Unmarshal(retval, iksLB); err != nil {
-		return fmt.Errorf("error parsing load balancer response: %v", err)
+		return fmt.Errorf("error parsing update response for iks load balancer %s: %w", lbId, err)
 	}
 
-	backoffTimer := retry.NewConstant(common.DefaultRetryInterval)
-
-	if err := retry.Do(ctx, backoffTimer, func(_ context.Context) error {
-		iksLB, err = client.GetIKSLoadBalancerByID(ctx, clusterUUID, lbId)
-		if err != nil {
-			return fmt.Errorf("error reading node group state")
-		}
-		if iksLB.Status.State == "Active" {
-			return nil
-		} else {
-			return retry.RetryableError(fmt.Errorf("iks load balancer state not ready, retry again"))
-		}
-	}); err != nil {
-		return fmt.Errorf("iks node group state not ready after maximum retries")
+	if _, err := client.waitForIKSLoadBalancerActive(ctx, clusterUUID, lbId, 2); err != nil {
+		return err
 	}
 
 	return nil
@@ -1076,17 +1336,17 @@ func (client *IDCServicesClient) DeleteIKSLoadBalancer(ctx context.Context, clus
 	// Parse the template string with the provided data
 	parsedURL, err := client.APIClient.ParseString(deleteIKSLBURLByID, params)
 	if err != nil {
-		return fmt.Errorf("error parsing the url to delete IKS load balancer")
+		return fmt.Errorf("error parsing the url to delete iks load balancer %s: %w", lbId, err)
 	}
 
-	retcode, retval, err := common.MakeDeleteAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeDeleteAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return fmt.Errorf("error reading sshkey by resource id")
+		return fmt.Errorf("error deleting iks load balancer %s: %w", lbId, err)
 	}
 	tflog.Debug(ctx, "iks delete IKS load balancer by ID api response", map[string]any{"retcode": retcode, "retval": string(retval)})
 
 	if retcode != http.StatusOK {
-		return common.MapHttpError(retcode, retval)
+		return fmt.Errorf("error deleting iks load balancer %s: %w", lbId, common.MapHttpError(retcode, retval))
 	}
 
 	return nil
@@ -0,0 +1,142 @@
+package itacservices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+var (
+	getAllLoadBalancersURL = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/loadbalancers"
+	createLoadBalancerURL  = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/loadbalancers"
+	getLoadBalancerByID    = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/loadbalancers/id/{{.ResourceId}}"
+	updateLoadBalancerURL  = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/loadbalancers/id/{{.ResourceId}}"
+	deleteLoadBalancerURL  = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/loadbalancers/id/{{.ResourceId}}"
+)
+
+const (
+	LoadBalancerStateActive = "Active"
+	LoadBalancerStateFailed = "Failed"
+)
+
+type LoadBalancers struct {
+	LoadBalancers []LoadBalancer `json:"items"`
+	NextPageToken string         `json:"nextPageToken,omitempty"`
+}
+
+type LoadBalancer struct {
+	Metadata LoadBalancerMetadata  `json:"metadata"`
+	Spec     LoadBalancerSpec      `json:"spec"`
+	Status   IKSLoadBalancerStatus `json:"status"`
+}
+
+type LoadBalancerMetadata struct {
+	CloudAccountID  string            `json:"cloudAccountId"`
+	Name            string            `json:"name"`
+	ResourceID      string            `json:"resourceId"`
+	ResourceVersion string            `json:"resourceVersion"`
+	Labels          map[string]string `json:"labels,omitempty"`
+}
+
+type LoadBalancerCreateRequest struct {
+	Metadata struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels,omitempty"`
+	} `json:"metadata"`
+	Spec LoadBalancerSpec `json:"spec"`
+}
+
+type LoadBalancerUpdateRequest struct {
+	Spec LoadBalancerUpdateSpec `json:"spec"`
+}
+
+type LoadBalancerUpdateSpec struct {
+	Listeners []LoadBalancerListener  `json:"listeners"`
+	Security  IKSLoadBalancerSecurity `json:"security"`
+}
+
+func (client *IDCServicesClient) CreateLoadBalancer(ctx context.Context, in *LoadBalancerCreateRequest) (*LoadBalancer, error) {
+	lb, err := createResource[LoadBalancer](ctx, client, "load balancer", in.Metadata.Name, createLoadBalancerURL, in)
+	if err != nil {
+		return nil, err
+	}
+	return client.waitForLoadBalancerActive(ctx, lb.Metadata.ResourceID, 1)
+}
+
+func (client *IDCServicesClient) GetLoadBalancers(ctx context.Context) (*LoadBalancers, error) {
+	items, err := listResources(ctx, client, "load balancers", getAllLoadBalancersURL, func(retval []byte) ([]LoadBalancer, string, error) {
+		page := LoadBalancers{}
+		err := json.Unmarshal(retval, &page)
+		return page.LoadBalancers, page.NextPageToken, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &LoadBalancers{LoadBalancers: items}, nil
+}
+
+func (client *IDCServicesClient) GetLoadBalancerByID(ctx context.Context, resourceId string) (*LoadBalancer, error) {
+	return getResourceByID[LoadBalancer](ctx, client, "load balancer", getLoadBalancerByID, resourceId)
+}
+
+func (client *IDCServicesClient) UpdateLoadBalancer(ctx context.Context, resourceId string, in *LoadBalancerUpdateRequest) (*LoadBalancer, error) {
+	if err := updateResourceByID(ctx, client, "load balancer", updateLoadBalancerURL, resourceId, in); err != nil {
+		return nil, err
+	}
+	return client.waitForLoadBalancerActive(ctx, resourceId, 2)
+}
+
+func (client *IDCServicesClient) DeleteLoadBalancer(ctx context.Context, resourceId string) error {
+	return deleteResourceByID(ctx, client, "load balancer", deleteLoadBalancerURL, resourceId)
+}
+
+// waitForLoadBalancerActive waits until the load balancer is active, seeing it active on
+// minTargetReads consecutive reads. A failed load balancer or listener stops the wait with the
+// message reported by the backend.
+func (client *IDCServicesClient) waitForLoadBalancerActive(ctx context.Context, resourceId string, minTargetReads int) (*LoadBalancer, error) {
+	waiter := client.loadBalancerWaiter(resourceId)
+	waiter.Target = []string{LoadBalancerStateActive}
+	waiter.Failure = []string{LoadBalancerStateFailed}
+	waiter.Check = func(lb *LoadBalancer) error {
+		return loadBalancerStatusError(&lb.Status)
+	}
+	waiter.MinTargetReads = minTargetReads
+	return waiter.Wait(ctx)
+}
+
+// WaitForLoadBalancerDeleted waits until the load balancer is gone after a delete request.
+func (client *IDCServicesClient) WaitForLoadBalancerDeleted(ctx context.Context, resourceId string) error {
+	waiter := client.loadBalancerWaiter(resourceId)
+	waiter.Goal = "deleted"
+	waiter.TargetNotFound = true
+	_, err := waiter.Wait(ctx)
+	return err
+}
+
+func (client *IDCServicesClient) loadBalancerWaiter(resourceId string) *Waiter[LoadBalancer] {
+	return &Waiter[LoadBalancer]{
+		Noun: "load balancer",
+		ID:   resourceId,
+		Refresh: func(ctx context.Context) (*LoadBalancer, string, error) {
+			lb, err := client.GetLoadBalancerByID(ctx, resourceId)
+			if err != nil {
+				return nil, "", err
+			}
+			return lb, lb.Status.State, nil
+		},
+		Message: func(lb *LoadBalancer) string {
+			return lb.Status.Message
+		},
+	}
+}
+
+// loadBalancerStatusError returns the error reported by the backend for the first failed
+// listener of a load balancer, and nil while no listener has failed.
+func loadBalancerStatusError(status *IKSLoadBalancerStatus) error {
+	for _, listener := range status.Listeners {
+		if listener.State == LoadBalancerStateFailed {
+			return fmt.Errorf("load balancer listener %d failed: %s", listener.Port, listener.Message)
+		}
+	}
+	return nil
+}
@@ -5,19 +5,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"terraform-provider-intelcloud/pkg/itacservices/common"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	retry "github.com/sethvargo/go-retry"
 )
 
 const (
+	getAllObjectStorageBucketsURL         = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/objects/buckets"
+	getAllObjectStorageUsersURL           = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/objects/users"
 	createObjectStorageBucketURL          = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/objects/buckets"
 	getObjectStorageBucketByResourceId    = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/objects/buckets/id/{{.ResourceId}}"
+	getObjectStorageBucketByName          = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/objects/buckets/name/{{.Name}}"
 	deleteObjectStorageBucketByResourceId = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/objects/buckets/id/{{.ResourceId}}"
 	createObjectStorageUserURL            = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/objects/users"
 	deleteObjectStorageUserURL            = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/objects/users/id/{{.ResourceId}}"
 	getObjectStorageUserURL               = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/objects/users/id/{{.ResourceId}}"
+	getObjectStorageUserByName            = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/objects/users/name/{{.Name}}"
+	updateObjectStorageBucketSecurityURL  = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/objects/buckets/id/{{.ResourceId}}/securitygroup"
+)
+
+const (
+	BucketPhaseProvisioning = "BucketProvisioning"
+	BucketPhaseReady        = "BucketReady"
+	BucketPhaseFailed       = "BucketFailed"
 )
 
 type ObjectBucketCreateRequest struct {
@@ -30,6 +41,11 @@ type ObjectBucketCreateRequest struct {
 	} `json:"spec"`
 }
 
+type ObjectBuckets struct {
+	Buckets       []ObjectBucket `json:"buckets"`
+	NextPageToken string         `json:"nextPageToken,omitempty"`
+}
+
 type ObjectBucket struct {
 	Metadata struct {
 		Name         string `json:"name"`
@@ -45,20 +61,28 @@ type ObjectBucket struct {
 	} `json:"spec"`
 	Status struct {
 		Phase   string `json:"phase"`
+		Message string `json:"message"`
 		Cluster struct {
 			AccessEndpoint string `json:"accessEndpoint"`
 			ClusterId      string `json:"clusterId"`
 		} `json:"cluster"`
 		SecurityGroups struct {
-			NetworkFilterAllow []struct {
-				Gateway      string `json:"gateway"`
-				PrefixLength int    `json:"prefixLength"`
-				Subnet       string `json:"subnet"`
-			} `json:"networkFilterAllow"`
+			NetworkFilterAllow []NetworkFilter `json:"networkFilterAllow"`
 		} `json:"securityGroup"`
 	} `json:"status"`
 }
 
+// NetworkFilter is a subnet allowed to reach the private endpoint of a bucket.
+type NetworkFilter struct {
+	Gateway      string `json:"gateway"`
+	PrefixLength int    `json:"prefixLength"`
+	Subnet       string `json:"subnet"`
+}
+
+type ObjectBucketSecurityGroupUpdateRequest struct {
+	NetworkFilterAllow []NetworkFilter `json:"networkFilterAllow"`
+}
+
 type ObjectUserCreateRequest struct {
 	Metadata struct {
 		Name string `json:"name"`
@@ -73,6 +97,11 @@ type BucketPolicy struct {
 	Prefix      string   `json:"prefix"`
 }
 
+type ObjectUsers struct {
+	Users         []ObjectUser `json:"users"`
+	NextPageToken string       `json:"nextPageToken,omitempty"`
+}
+
 type ObjectUser struct {
 	Metadata struct {
 		Name         string `json:"name"`
@@ -103,50 +132,94 @@ func (client *IDCServicesClient) CreateObjectStorageBucket(ctx context.Context,
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(createObjectStorageBucketURL, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to create bucket %s: %w", in.Metadata.Name, err)
 	}
 
 	inArgs, err := json.MarshalIndent(in, "", "    ")
 	if err != nil {
-		return nil, fmt.Errorf("error parsing input arguments")
+		return nil, fmt.Errorf("error encoding create request for bucket %s: %w", in.Metadata.Name, err)
 	}
 
 	tflog.Debug(ctx, "bucket create api", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
-	retcode, retval, err := common.MakePOSTAPICall(ctx, parsedURL, *client.Apitoken, inArgs)
+	retcode, retval, err := common.MakePOSTAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), inArgs)
 	tflog.Debug(ctx, "bucket create api", map[string]any{"retcode": retcode, "retval": string(retval)})
 	if err != nil {
-		return nil, fmt.Errorf("error reading bucket create response")
+		return nil, fmt.Errorf("error creating bucket %s: %w", in.Metadata.Name, err)
 	}
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error creating bucket %s: %w", in.Metadata.Name, common.MapHttpError(retcode, retval))
 	}
 
 	bucket := &ObjectBucket{}
 	if err := json.Unmarshal(retval, bucket); err != nil {
-		return nil, fmt.Errorf("error parsing bucket response")
+		return nil, fmt.Errorf("error parsing create response for bucket %s: %w", in.Metadata.Name, err)
 	}
+	resourceId := bucket.Metadata.ResourceId
 
-	backoffTimer := retry.NewConstant(common.DefaultRetryInterval)
-
-	if err := retry.Do(ctx, backoffTimer, func(_ context.Context) error {
-		bucket, err = client.GetObjectBucketByResourceId(ctx, bucket.Metadata.ResourceId)
-		if err != nil {
-			return fmt.Errorf("error reading bucket state")
-		}
-		if bucket.Status.Phase == "BucketReady" {
-			return nil
-		} else if bucket.Status.Phase == "BucketFailed" {
-			return fmt.Errorf("bucket state failed")
-		} else {
-			return retry.RetryableError(fmt.Errorf("bucket state not ready, retry again"))
-		}
-	}); err != nil {
-		return nil, fmt.Errorf("bucket state not ready after maximum retries")
+	waiter := client.bucketWaiter(resourceId)
+	waiter.Pending = []string{"", BucketPhaseProvisioning}
+	waiter.Target = []string{BucketPhaseReady}
+	waiter.Failure = []string{BucketPhaseFailed}
+	bucket, err = waiter.Wait(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	return bucket, nil
 }
 
+// WaitForBucketDeleted waits until the bucket is gone after a delete request.
+func (client *IDCServicesClient) WaitForBucketDeleted(ctx context.Context, resourceId string) error {
+	waiter := client.bucketWaiter(resourceId)
+	waiter.Goal = "deleted"
+	waiter.TargetNotFound = true
+	_, err := waiter.Wait(ctx)
+	return err
+}
+
+func (client *IDCServicesClient) bucketWaiter(resourceId string) *Waiter[ObjectBucket] {
+	return &Waiter[ObjectBucket]{
+		Noun: "bucket",
+		ID:   resourceId,
+		Refresh: func(ctx context.Context) (*ObjectBucket, string, error) {
+			bucket, err := client.GetObjectBucketByResourceId(ctx, resourceId)
+			if err != nil {
+				return nil, "", err
+			}
+			return bucket, bucket.Status.Phase, nil
+		},
+		Message: func(bucket *ObjectBucket) string {
+			return bucket.Status.Message
+		},
+	}
+}
+
+func (client *IDCServicesClient) GetObjectBuckets(ctx context.Context) (*ObjectBuckets, error) {
+	params := struct {
+		Host         string
+		Cloudaccount string
+	}{
+		Host:         *client.Host,
+		Cloudaccount: *client.Cloudaccount,
+	}
+
+	// Parse the template string with the provided data
+	parsedURL, err := common.ParseString(getAllObjectStorageBucketsURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the url to list buckets: %w", err)
+	}
+
+	items, err := newPageIterator("buckets", parsedURL, client.commonGet, func(retval []byte) ([]ObjectBucket, string, error) {
+		page := ObjectBuckets{}
+		err := json.Unmarshal(retval, &page)
+		return page.Buckets, page.NextPageToken, err
+	}).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectBuckets{Buckets: items}, nil
+}
+
 func (client *IDCServicesClient) GetObjectBucketByResourceId(ctx context.Context, resourceId string) (*ObjectBucket, error) {
 	params := struct {
 		Host         string
@@ -161,22 +234,57 @@ func (client *IDCServicesClient) GetObjectBucketByResourceId(ctx context.Context
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(getObjectStorageBucketByResourceId, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to read bucket %s: %w", resourceId, err)
 	}
 
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeGetAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return nil, fmt.Errorf("error reading bucket by resource id")
+		return nil, fmt.Errorf("error reading bucket %s: %w", resourceId, err)
 	}
 
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error reading bucket %s: %w", resourceId, common.MapHttpError(retcode, retval))
 	}
 
 	tflog.Debug(ctx, "object read api", map[string]any{"retcode": retcode})
 	bucket := ObjectBucket{}
 	if err := json.Unmarshal(retval, &bucket); err != nil {
-		return nil, fmt.Errorf("error parsing bucket response")
+		return nil, fmt.Errorf("error parsing bucket %s response: %w", resourceId, err)
+	}
+	return &bucket, nil
+}
+
+// GetObjectBucketByName returns the bucket with the given name.
+func (client *IDCServicesClient) GetObjectBucketByName(ctx context.Context, name string) (*ObjectBucket, error) {
+	params := struct {
+		Host         string
+		Cloudaccount string
+		Name         string
+	}{
+		Host:         *client.Host,
+		Cloudaccount: *client.Cloudaccount,
+		Name:         url.PathEscape(name),
+	}
+
+	// Parse the template string with the provided data
+	parsedURL, err := common.ParseString(getObjectStorageBucketByName, params)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the url to read bucket %s: %w", name, err)
+	}
+
+	retcode, retval, err := common.MakeGetAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error reading bucket %s: %w", name, err)
+	}
+
+	if retcode != http.StatusOK {
+		return nil, fmt.Errorf("error reading bucket %s: %w", name, common.MapHttpError(retcode, retval))
+	}
+
+	tflog.Debug(ctx, "object read api", map[string]any{"retcode": retcode})
+	bucket := ObjectBucket{}
+	if err := json.Unmarshal(retval, &bucket); err != nil {
+		return nil, fmt.Errorf("error parsing bucket %s response: %w", name, err)
 	}
 	return &bucket, nil
 }
@@ -195,18 +303,18 @@ func (client *IDCServicesClient) DeleteBucketByResourceId(ctx context.Context, r
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(deleteObjectStorageBucketByResourceId, params)
 	if err != nil {
-		return fmt.Errorf("error parsing the url")
+		return fmt.Errorf("error parsing the url to delete bucket %s: %w", resourceId, err)
 	}
 
-	retcode, retval, err := common.MakeDeleteAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeDeleteAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return fmt.Errorf("error deleting object bucket by resource id")
+		return fmt.Errorf("error deleting bucket %s: %w", resourceId, err)
 	}
 
 	tflog.Debug(ctx, "object bucket delete api", map[string]any{"retcode": retcode, "retval": string(retval)})
 
 	if retcode != http.StatusOK {
-		return common.MapHttpError(retcode, retval)
+		return fmt.Errorf("error deleting bucket %s: %w", resourceId, common.MapHttpError(retcode, retval))
 	}
 
 	tflog.Debug(ctx, "object bucket delete api", map[string]any{"retcode": retcode})
@@ -214,6 +322,45 @@ func (client *IDCServicesClient) DeleteBucketByResourceId(ctx context.Context, r
 	return nil
 }
 
+// UpdateObjectBucketSecurityGroup replaces the subnets allowed to reach the bucket and returns the updated bucket.
+func (client *IDCServicesClient) UpdateObjectBucketSecurityGroup(ctx context.Context, resourceId string, filters []NetworkFilter) (*ObjectBucket, error) {
+	params := struct {
+		Host         string
+		Cloudaccount string
+		ResourceId   string
+	}{
+		Host:         *client.Host,
+		Cloudaccount: *client.Cloudaccount,
+		ResourceId:   resourceId,
+	}
+
+	// Parse the template string with the provided data
+	parsedURL, err := common.ParseString(updateObjectStorageBucketSecurityURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the url to update security group of bucket %s: %w", resourceId, err)
+	}
+
+	if filters == nil {
+		filters = []NetworkFilter{}
+	}
+	inArgs, err := json.MarshalIndent(ObjectBucketSecurityGroupUpdateRequest{NetworkFilterAllow: filters}, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("error encoding security group request for bucket %s: %w", resourceId, err)
+	}
+
+	tflog.Debug(ctx, "bucket security group update api", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
+	retcode, retval, err := common.MakePutAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), inArgs)
+	tflog.Debug(ctx, "bucket security group update api", map[string]any{"retcode": retcode, "retval": string(retval)})
+	if err != nil {
+		return nil, fmt.Errorf("error updating security group of bucket %s: %w", resourceId, err)
+	}
+	if retcode != http.StatusOK {
+		return nil, fmt.Errorf("error updating security group of bucket %s: %w", resourceId, common.MapHttpError(retcode, retval))
+	}
+
+	return client.GetObjectBucketByResourceId(ctx, resourceId)
+}
+
 func (client *IDCServicesClient) CreateObjectStorageUser(ctx context.Context, in *ObjectUserCreateRequest) (*ObjectUser, error) {
 	params := struct {
 		Host         string
@@ -226,27 +373,27 @@ func (client *IDCServicesClient) CreateObjectStorageUser(ctx context.Context, in
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(createObjectStorageUserURL, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to create bucket user %s: %w", in.Metadata.Name, err)
 	}
 
 	inArgs, err := json.MarshalIndent(in, "", "    ")
 	if err != nil {
-		return nil, fmt.Errorf("error parsing input arguments")
+		return nil, fmt.Errorf("error encoding create request for bucket user %s: %w", in.Metadata.Name, err)
 	}
 
 	tflog.Debug(ctx, "bucket user create api", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
-	retcode, retval, err := common.MakePOSTAPICall(ctx, parsedURL, *client.Apitoken, inArgs)
+	retcode, retval, err := common.MakePOSTAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), inArgs)
 	tflog.Debug(ctx, "bucket user create api", map[string]any{"retcode": retcode, "retval": string(retval)})
 	if err != nil {
-		return nil, fmt.Errorf("error reading bucket user create response")
+		return nil, fmt.Errorf("error creating bucket user %s: %w", in.Metadata.Name, err)
 	}
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error creating bucket user %s: %w", in.Metadata.Name, common.MapHttpError(retcode, retval))
 	}
 
 	objUser := &ObjectUser{}
 	if err := json.Unmarshal(retval, objUser); err != nil {
-		return nil, fmt.Errorf("error parsing bucket user response")
+		return nil, fmt.Errorf("error parsing create response for bucket user %s: %w", in.Metadata.Name, err)
 	}
 	tflog.Debug(ctx, "bucket user create api", map[string]any{"retcode": retcode, "ret object": objUser})
 	return objUser, nil
@@ -266,18 +413,18 @@ func (client *IDCServicesClient) DeleteObjectUserByResourceId(ctx context.Contex
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(deleteObjectStorageUserURL, params)
 	if err != nil {
-		return fmt.Errorf("error parsing the url")
+		return fmt.Errorf("error parsing the url to delete bucket user %s: %w", userId, err)
 	}
 
-	retcode, retval, err := common.MakeDeleteAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeDeleteAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return fmt.Errorf("error deleting object bucket user by id")
+		return fmt.Errorf("error deleting bucket user %s: %w", userId, err)
 	}
 
 	tflog.Debug(ctx, "object bucket user delete api", map[string]any{"retcode": retcode, "retval": string(retval)})
 
 	if retcode != http.StatusOK {
-		return common.MapHttpError(retcode, retval)
+		return fmt.Errorf("error deleting bucket user %s: %w", userId, common.MapHttpError(retcode, retval))
 	}
 
 	tflog.Debug(ctx, "object bucket user delete api", map[string]any{"retcode": retcode})
@@ -285,6 +432,32 @@ func (client *IDCServicesClient) DeleteObjectUserByResourceId(ctx context.Contex
 	return nil
 }
 
+func (client *IDCServicesClient) GetObjectUsers(ctx context.Context) (*ObjectUsers, error) {
+	params := struct {
+		Host         string
+		Cloudaccount string
+	}{
+		Host:         *client.Host,
+		Cloudaccount: *client.Cloudaccount,
+	}
+
+	// Parse the template string with the provided data
+	parsedURL, err := common.ParseString(getAllObjectStorageUsersURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the url to list bucket users: %w", err)
+	}
+
+	items, err := newPageIterator("bucket users", parsedURL, client.commonGet, func(retval []byte) ([]ObjectUser, string, error) {
+		page := ObjectUsers{}
+		err := json.Unmarshal(retval, &page)
+		return page.Users, page.NextPageToken, err
+	}).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectUsers{Users: items}, nil
+}
+
 func (client *IDCServicesClient) GetObjectUserByUserId(ctx context.Context, userId string) (*ObjectUser, error) {
 	params := struct {
 		Host         string
@@ -299,22 +472,57 @@ func (client *IDCServicesClient) GetObjectUserByUserId(ctx context.Context, user
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(getObjectStorageUserURL, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to read bucket user %s: %w", userId, err)
+	}
+
+	retcode, retval, err := common.MakeGetAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error reading bucket user %s: %w", userId, err)
+	}
+
+	if retcode != http.StatusOK {
+		return nil, fmt.Errorf("error reading bucket user %s: %w", userId, common.MapHttpError(retcode, retval))
+	}
+
+	tflog.Debug(ctx, "object user read api", map[string]any{"retcode": retcode})
+	user := ObjectUser{}
+	if err := json.Unmarshal(retval, &user); err != nil {
+		return nil, fmt.Errorf("error parsing bucket user %s response: %w", userId, err)
+	}
+	return &user, nil
+}
+
+// GetObjectUserByName returns the bucket user with the given name.
+func (client *IDCServicesClient) GetObjectUserByName(ctx context.Context, name string) (*ObjectUser, error) {
+	params := struct {
+		Host         string
+		Cloudaccount string
+		Name         string
+	}{
+		Host:         *client.Host,
+		Cloudaccount: *client.Cloudaccount,
+		Name:         url.PathEscape(name),
+	}
+
+	// Parse the template string with the provided data
+	parsedURL, err := common.ParseString(getObjectStorageUserByName, params)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the url to read bucket user %s: %w", name, err)
 	}
 
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeGetAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return nil, fmt.Errorf("error reading bucket user by id")
+		return nil, fmt.Errorf("error reading bucket user %s: %w", name, err)
 	}
 
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error reading bucket user %s: %w", name, common.MapHttpError(retcode, retval))
 	}
 
 	tflog.Debug(ctx, "object user read api", map[string]any{"retcode": retcode})
 	user := ObjectUser{}
 	if err := json.Unmarshal(retval, &user); err != nil {
-		return nil, fmt.Errorf("error parsing bucket response")
+		return nil, fmt.Errorf("error parsing bucket user %s response: %w", name, err)
 	}
 	return &user, nil
 }
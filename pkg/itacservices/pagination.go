@@ -0,0 +1,152 @@
+package itacservices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultPageSize is the number of items requested per page by list calls.
+const DefaultPageSize = 100
+
+const (
+	pageSizeParam  = "pageSize"
+	pageTokenParam = "pageToken"
+)
+
+// pageFetcher performs a GET on a fully built list url.
+type pageFetcher func(ctx context.Context, url string) (int, []byte, error)
+
+// pageDecoder extracts the items and the continuation token from one page of a list
+// response. An empty token marks the last page.
+type pageDecoder[T any] func(retval []byte) ([]T, string, error)
+
+// PageIterator walks a paginated list endpoint one page at a time, following the
+// continuation token returned with each page until the service stops sending one.
+type PageIterator[T any] struct {
+	kind     string
+	listURL  string
+	pageSize int
+	fetch    pageFetcher
+	decode   pageDecoder[T]
+
+	token string
+	seen  map[string]bool
+	page  []T
+	done  bool
+	err   error
+}
+
+func newPageIterator[T any](kind, listURL string, fetch pageFetcher, decode pageDecoder[T]) *PageIterator[T] {
+	return &PageIterator[T]{
+		kind:     kind,
+		listURL:  listURL,
+		pageSize: DefaultPageSize,
+		fetch:    fetch,
+		decode:   decode,
+		seen:     map[string]bool{},
+	}
+}
+
+// Next fetches the next page and reports whether one was read. It returns false once
+// the last page has been consumed or an error occurred; check Err afterwards.
+func (it *PageIterator[T]) Next(ctx context.Context) bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	pageURL, err := withPageParams(it.listURL, it.pageSize, it.token)
+	if err != nil {
+		it.err = fmt.Errorf("error parsing the url to list %s: %w", it.kind, err)
+		return false
+	}
+
+	retcode, retval, err := it.fetch(ctx, pageURL)
+	tflog.Debug(ctx, it.kind+" list api page", map[string]any{"url": pageURL, "retcode": retcode})
+	if err != nil {
+		it.err = fmt.Errorf("error listing %s: %w", it.kind, err)
+		return false
+	}
+	if retcode != http.StatusOK {
+		it.err = fmt.Errorf("error listing %s: %w", it.kind, common.MapHttpError(retcode, retval))
+		return false
+	}
+
+	items, next, err := it.decode(retval)
+	if err != nil {
+		it.err = fmt.Errorf("error parsing %s list response: %w", it.kind, err)
+		return false
+	}
+
+	it.page = items
+	if next == "" {
+		it.done = true
+		return true
+	}
+	if it.seen[next] {
+		it.err = fmt.Errorf("error listing %s: page token %q returned twice", it.kind, next)
+		return false
+	}
+	it.seen[next] = true
+	it.token = next
+	return true
+}
+
+// Page returns the items of the page read by the last call to Next.
+func (it *PageIterator[T]) Page() []T {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+// All reads every remaining page and returns their items in order.
+func (it *PageIterator[T]) All(ctx context.Context) ([]T, error) {
+	items := []T{}
+	for it.Next(ctx) {
+		items = append(items, it.Page()...)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// withPageParams adds the page size and continuation token to listURL, keeping any
+// query it already carries.
+func withPageParams(listURL string, pageSize int, pageToken string) (string, error) {
+	u, err := url.Parse(listURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	if pageSize > 0 {
+		q.Set(pageSizeParam, strconv.Itoa(pageSize))
+	}
+	if pageToken != "" {
+		q.Set(pageTokenParam, pageToken)
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// commonGet fetches a page through the package level http helpers.
+func (client *IDCServicesClient) commonGet(ctx context.Context, pageURL string) (int, []byte, error) {
+	return common.MakeGetAPICall(ctx, client.HTTPClient, pageURL, client.accessToken(ctx), nil)
+}
+
+// apiClientGet fetches a page through the client's APIClient.
+func (client *IDCServicesClient) apiClientGet(ctx context.Context, pageURL string) (int, []byte, error) {
+	return client.APIClient.MakeGetAPICall(ctx, pageURL, client.accessToken(ctx), nil)
+}
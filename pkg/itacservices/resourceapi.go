@@ -0,0 +1,138 @@
+package itacservices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The helpers below serve the cloud account collections that follow the same layout, such as
+// load balancers and firewall rules: the collection is listed and created at its root and a
+// single resource is read, updated and deleted at <collection>/id/<resourceId>. The URL
+// templates take the Host, Cloudaccount and ResourceId parameters.
+
+// resourceURL parses the URL template of a cloud account resource.
+func (client *IDCServicesClient) resourceURL(urlTemplate, resourceId string) (string, error) {
+	params := struct {
+		Host         string
+		Cloudaccount string
+		ResourceId   string
+	}{
+		Host:         *client.Host,
+		Cloudaccount: *client.Cloudaccount,
+		ResourceId:   resourceId,
+	}
+	return client.APIClient.ParseString(urlTemplate, params)
+}
+
+// listResources reads every page of a collection, collecting the items decode returns.
+func listResources[T any](ctx context.Context, client *IDCServicesClient, nouns, urlTemplate string, decode pageDecoder[T]) ([]T, error) {
+	parsedURL, err := client.resourceURL(urlTemplate, "")
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the url to list %s: %w", nouns, err)
+	}
+	return newPageIterator(nouns, parsedURL, client.apiClientGet, decode).All(ctx)
+}
+
+// createResource posts in to a collection and returns the resource the API created.
+func createResource[T any](ctx context.Context, client *IDCServicesClient, noun, name, urlTemplate string, in any) (*T, error) {
+	parsedURL, err := client.resourceURL(urlTemplate, "")
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the url to create %s %s: %w", noun, name, err)
+	}
+
+	inArgs, err := json.MarshalIndent(in, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("error encoding create request for %s %s: %w", noun, name, err)
+	}
+
+	tflog.Debug(ctx, noun+" create api request", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
+	retcode, retval, err := client.APIClient.MakePOSTAPICall(ctx, parsedURL, client.accessToken(ctx), inArgs)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s %s: %w", noun, name, err)
+	}
+	tflog.Debug(ctx, noun+" create api response", map[string]any{"retcode": retcode, "retval": string(retval)})
+
+	if retcode != http.StatusOK {
+		return nil, fmt.Errorf("error creating %s %s: %w", noun, name, common.MapHttpError(retcode, retval))
+	}
+
+	created := new(T)
+	if err := json.Unmarshal(retval, created); err != nil {
+		return nil, fmt.Errorf("error parsing create response for %s %s: %w", noun, name, err)
+	}
+	return created, nil
+}
+
+// getResourceByID reads a single resource.
+func getResourceByID[T any](ctx context.Context, client *IDCServicesClient, noun, urlTemplate, resourceId string) (*T, error) {
+	parsedURL, err := client.resourceURL(urlTemplate, resourceId)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the url to read %s %s: %w", noun, resourceId, err)
+	}
+
+	retcode, retval, err := client.APIClient.MakeGetAPICall(ctx, parsedURL, client.accessToken(ctx), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s %s: %w", noun, resourceId, err)
+	}
+	tflog.Debug(ctx, noun+" read api response", map[string]any{"retcode": retcode, "retval": string(retval)})
+
+	if retcode != http.StatusOK {
+		return nil, fmt.Errorf("error reading %s %s: %w", noun, resourceId, common.MapHttpError(retcode, retval))
+	}
+
+	resource := new(T)
+	if err := json.Unmarshal(retval, resource); err != nil {
+		return nil, fmt.Errorf("error parsing %s %s response: %w", noun, resourceId, err)
+	}
+	return resource, nil
+}
+
+// updateResourceByID puts in to a single resource.
+func updateResourceByID(ctx context.Context, client *IDCServicesClient, noun, urlTemplate, resourceId string, in any) error {
+	parsedURL, err := client.resourceURL(urlTemplate, resourceId)
+	if err != nil {
+		return fmt.Errorf("error parsing the url to update %s %s: %w", noun, resourceId, err)
+	}
+
+	inArgs, err := json.MarshalIndent(in, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error encoding update request for %s %s: %w", noun, resourceId, err)
+	}
+
+	tflog.Debug(ctx, noun+" update api request", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
+	retcode, retval, err := client.APIClient.MakePutAPICall(ctx, parsedURL, client.accessToken(ctx), inArgs)
+	if err != nil {
+		return fmt.Errorf("error updating %s %s: %w", noun, resourceId, err)
+	}
+	tflog.Debug(ctx, noun+" update api response", map[string]any{"retcode": retcode, "retval": string(retval)})
+
+	if retcode != http.StatusOK {
+		return fmt.Errorf("error updating %s %s: %w", noun, resourceId, common.MapHttpError(retcode, retval))
+	}
+	return nil
+}
+
+// deleteResourceByID deletes a single resource. The API removes it asynchronously.
+func deleteResourceByID(ctx context.Context, client *IDCServicesClient, noun, urlTemplate, resourceId string) error {
+	parsedURL, err := client.resourceURL(urlTemplate, resourceId)
+	if err != nil {
+		return fmt.Errorf("error parsing the url to delete %s %s: %w", noun, resourceId, err)
+	}
+
+	retcode, retval, err := client.APIClient.MakeDeleteAPICall(ctx, parsedURL, client.accessToken(ctx), nil)
+	if err != nil {
+		return fmt.Errorf("error deleting %s %s: %w", noun, resourceId, err)
+	}
+	tflog.Debug(ctx, noun+" delete api response", map[string]any{"retcode": retcode, "retval": string(retval)})
+
+	if retcode != http.StatusOK {
+		return fmt.Errorf("error deleting %s %s: %w", noun, resourceId, common.MapHttpError(retcode, retval))
+	}
+	return nil
+}
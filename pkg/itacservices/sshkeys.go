@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"terraform-provider-intelcloud/pkg/itacservices/common"
 
@@ -15,11 +16,14 @@ var (
 	getAllSSHKeysURLByAccount = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/sshpublickeys"
 	createSSHKeyURL           = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/sshpublickeys"
 	getSSHKeyByResourceId     = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/sshpublickeys/id/{{.ResourceId}}"
+	getSSHKeyByName           = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/sshpublickeys/name/{{.Name}}"
 	deleteSSHKeyByResourceId  = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/sshpublickeys/id/{{.ResourceId}}"
+	updateSSHKeyByResourceId  = "{{.Host}}/v1/cloudaccounts/{{.Cloudaccount}}/sshpublickeys/id/{{.ResourceId}}"
 )
 
 type SSHKeys struct {
-	SSHKey []SSHKey `json:"items"`
+	SSHKey        []SSHKey `json:"items"`
+	NextPageToken string   `json:"nextPageToken,omitempty"`
 }
 
 type SSHKey struct {
@@ -37,13 +41,20 @@ type SSHKey struct {
 
 type SSHKeyCreateRequest struct {
 	Metadata struct {
-		Name string `json:"name"`
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
 	} `json:"metadata"`
 	Spec struct {
 		SSHPublicKey string `json:"sshPublicKey"`
 	} `json:"spec"`
 }
 
+type SSHKeyUpdateRequest struct {
+	Metadata struct {
+		Description string `json:"description"`
+	} `json:"metadata"`
+}
+
 func (client *IDCServicesClient) GetSSHKeys(ctx context.Context) (*SSHKeys, error) {
 	params := struct {
 		Host         string
@@ -56,23 +67,18 @@ func (client *IDCServicesClient) GetSSHKeys(ctx context.Context) (*SSHKeys, erro
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(getAllSSHKeysURLByAccount, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to list sshkeys: %w", err)
 	}
 
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
-	tflog.Debug(ctx, "sshkeys read api", map[string]any{"retcode": retcode, "retval": string(retval)})
+	items, err := newPageIterator("sshkeys", parsedURL, client.commonGet, func(retval []byte) ([]SSHKey, string, error) {
+		page := SSHKeys{}
+		err := json.Unmarshal(retval, &page)
+		return page.SSHKey, page.NextPageToken, err
+	}).All(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error reading sshkeys")
-	}
-	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
-	}
-
-	sshkeys := SSHKeys{}
-	if err := json.Unmarshal(retval, &sshkeys); err != nil {
-		return nil, fmt.Errorf("error parsing sshkey response")
+		return nil, err
 	}
-	return &sshkeys, nil
+	return &SSHKeys{SSHKey: items}, nil
 }
 
 func (client *IDCServicesClient) CreateSSHkey(ctx context.Context, in *SSHKeyCreateRequest) (*SSHKey, error) {
@@ -87,28 +93,28 @@ func (client *IDCServicesClient) CreateSSHkey(ctx context.Context, in *SSHKeyCre
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(createSSHKeyURL, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to create sshkey %s: %w", in.Metadata.Name, err)
 	}
 
 	inArgs, err := json.MarshalIndent(in, "", "    ")
 	if err != nil {
-		return nil, fmt.Errorf("error parsing input arguments")
+		return nil, fmt.Errorf("error encoding create request for sshkey %s: %w", in.Metadata.Name, err)
 	}
 
 	tflog.Debug(ctx, "sshkey create api request", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
-	retcode, retval, err := common.MakePOSTAPICall(ctx, parsedURL, *client.Apitoken, inArgs)
+	retcode, retval, err := common.MakePOSTAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), inArgs)
 	tflog.Debug(ctx, "sshkey create api response", map[string]any{"retcode": retcode, "retval": string(retval)})
 	if err != nil {
-		return nil, fmt.Errorf("error reading sshkey create response")
+		return nil, fmt.Errorf("error creating sshkey %s: %w", in.Metadata.Name, err)
 	}
 
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error creating sshkey %s: %w", in.Metadata.Name, common.MapHttpError(retcode, retval))
 	}
 
 	sshkey := SSHKey{}
 	if err := json.Unmarshal(retval, &sshkey); err != nil {
-		return nil, fmt.Errorf("error parsing sshkey response")
+		return nil, fmt.Errorf("error parsing create response for sshkey %s: %w", in.Metadata.Name, err)
 	}
 	return &sshkey, nil
 }
@@ -127,22 +133,57 @@ func (client *IDCServicesClient) GetSSHKeyByResourceId(ctx context.Context, reso
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(getSSHKeyByResourceId, params)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing the url")
+		return nil, fmt.Errorf("error parsing the url to read sshkey %s: %w", resourceId, err)
+	}
+
+	retcode, retval, err := common.MakeGetAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error reading sshkey %s: %w", resourceId, err)
+	}
+
+	if retcode != http.StatusOK {
+		return nil, fmt.Errorf("error reading sshkey %s: %w", resourceId, common.MapHttpError(retcode, retval))
+	}
+
+	tflog.Debug(ctx, "sshkey read api", map[string]any{"retcode": retcode})
+	sshkey := SSHKey{}
+	if err := json.Unmarshal(retval, &sshkey); err != nil {
+		return nil, fmt.Errorf("error parsing sshkey %s response: %w", resourceId, err)
+	}
+	return &sshkey, nil
+}
+
+// GetSSHKeyByName returns the sshkey with the given name.
+func (client *IDCServicesClient) GetSSHKeyByName(ctx context.Context, name string) (*SSHKey, error) {
+	params := struct {
+		Host         string
+		Cloudaccount string
+		Name         string
+	}{
+		Host:         *client.Host,
+		Cloudaccount: *client.Cloudaccount,
+		Name:         url.PathEscape(name),
+	}
+
+	// Parse the template string with the provided data
+	parsedURL, err := client.APIClient.ParseString(getSSHKeyByName, params)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the url to read sshkey %s: %w", name, err)
 	}
 
-	retcode, retval, err := common.MakeGetAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := client.APIClient.MakeGetAPICall(ctx, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return nil, fmt.Errorf("error reading sshkey by resource id")
+		return nil, fmt.Errorf("error reading sshkey %s: %w", name, err)
 	}
 
 	if retcode != http.StatusOK {
-		return nil, common.MapHttpError(retcode, retval)
+		return nil, fmt.Errorf("error reading sshkey %s: %w", name, common.MapHttpError(retcode, retval))
 	}
 
 	tflog.Debug(ctx, "sshkey read api", map[string]any{"retcode": retcode})
 	sshkey := SSHKey{}
 	if err := json.Unmarshal(retval, &sshkey); err != nil {
-		return nil, fmt.Errorf("error parsing sshkey response")
+		return nil, fmt.Errorf("error parsing sshkey %s response: %w", name, err)
 	}
 	return &sshkey, nil
 }
@@ -161,19 +202,55 @@ func (client *IDCServicesClient) DeleteSSHKeyByResourceId(ctx context.Context, r
 	// Parse the template string with the provided data
 	parsedURL, err := common.ParseString(deleteSSHKeyByResourceId, params)
 	if err != nil {
-		return fmt.Errorf("error parsing the url")
+		return fmt.Errorf("error parsing the url to delete sshkey %s: %w", resourceId, err)
 	}
 
-	retcode, retval, err := common.MakeDeleteAPICall(ctx, parsedURL, *client.Apitoken, nil)
+	retcode, retval, err := common.MakeDeleteAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), nil)
 	if err != nil {
-		return fmt.Errorf("error deleting sshkey by resource id")
+		return fmt.Errorf("error deleting sshkey %s: %w", resourceId, err)
 	}
 
 	if retcode != http.StatusOK {
-		return common.MapHttpError(retcode, retval)
+		return fmt.Errorf("error deleting sshkey %s: %w", resourceId, common.MapHttpError(retcode, retval))
 	}
 
 	tflog.Debug(ctx, "sshkey delete api", map[string]any{"retcode": retcode})
 
 	return nil
 }
+
+func (client *IDCServicesClient) UpdateSSHKey(ctx context.Context, resourceId string, in *SSHKeyUpdateRequest) error {
+	params := struct {
+		Host         string
+		Cloudaccount string
+		ResourceId   string
+	}{
+		Host:         *client.Host,
+		Cloudaccount: *client.Cloudaccount,
+		ResourceId:   resourceId,
+	}
+
+	// Parse the template string with the provided data
+	parsedURL, err := common.ParseString(updateSSHKeyByResourceId, params)
+	if err != nil {
+		return fmt.Errorf("error parsing the url to update sshkey %s: %w", resourceId, err)
+	}
+
+	inArgs, err := json.MarshalIndent(in, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error encoding update request for sshkey %s: %w", resourceId, err)
+	}
+
+	tflog.Debug(ctx, "sshkey update api request", map[string]any{"url": parsedURL, "inArgs": string(inArgs)})
+	retcode, retval, err := common.MakePutAPICall(ctx, client.HTTPClient, parsedURL, client.accessToken(ctx), inArgs)
+	tflog.Debug(ctx, "sshkey update api response", map[string]any{"retcode": retcode, "retval": string(retval)})
+	if err != nil {
+		return fmt.Errorf("error updating sshkey %s: %w", resourceId, err)
+	}
+
+	if retcode != http.StatusOK {
+		return fmt.Errorf("error updating sshkey %s: %w", resourceId, common.MapHttpError(retcode, retval))
+	}
+
+	return nil
+}
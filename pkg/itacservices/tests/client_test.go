@@ -0,0 +1,82 @@
+package itacservices_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"terraform-provider-intelcloud/pkg/fakeitac"
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForRegion(t *testing.T) {
+	_, client := newFakeClient(t)
+	dr := fakeitac.NewServer(t)
+	dr.CloudAccount = "210987654321"
+	ctx := context.Background()
+
+	catalog, err := common.LoadRegionCatalog(fmt.Sprintf(`
+regions:
+  - name: lab-region-2
+    api: %s
+    auth: %s
+    zones: [lab-region-2a]
+`, dr.URL, dr.URL))
+	require.NoError(t, err)
+	client.Regions = catalog
+
+	// the client's own region and account, given or defaulted, is the client itself
+	same, err := client.ForRegion(ctx, "", "")
+	require.NoError(t, err)
+	assert.Same(t, client, same)
+	same, err = client.ForRegion(ctx, "us-region-1", *client.Cloudaccount)
+	require.NoError(t, err)
+	assert.Same(t, client, same)
+
+	regional, err := client.ForRegion(ctx, "lab-region-2", dr.CloudAccount)
+	require.NoError(t, err)
+	assert.Equal(t, "lab-region-2", *regional.Region)
+	assert.Equal(t, dr.CloudAccount, *regional.Cloudaccount)
+	zone, err := regional.DefaultAvailabilityZone()
+	require.NoError(t, err)
+	assert.Equal(t, "lab-region-2a", zone)
+
+	in := &itacservices.SSHKeyCreateRequest{}
+	in.Metadata.Name = "dr-key"
+	_, err = regional.CreateSSHkey(ctx, in)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dr.Count("sshpublickeys"))
+
+	// clients are cached, also when derived from another derived client
+	cached, err := client.ForRegion(ctx, "lab-region-2", dr.CloudAccount)
+	require.NoError(t, err)
+	assert.Same(t, regional, cached)
+	back, err := regional.ForRegion(ctx, "us-region-1", *client.Cloudaccount)
+	require.NoError(t, err)
+	assert.Same(t, client, back)
+
+	_, err = client.ForRegion(ctx, "eu-region-1", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown region "eu-region-1"`)
+}
+
+func TestNewClient_LogsNoCredentials(t *testing.T) {
+	server := fakeitac.NewServer(t)
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+
+	client, err := itacservices.NewClient(ctx, strPtr(server.URL), strPtr(server.URL),
+		strPtr(server.CloudAccount), strPtr(server.ClientID), strPtr(server.ClientSecret), strPtr("us-region-1"), nil)
+	require.NoError(t, err)
+
+	basic := base64.StdEncoding.EncodeToString([]byte(server.ClientID + ":" + server.ClientSecret))
+	assert.Contains(t, logs.String(), "Token Response")
+	assert.NotContains(t, logs.String(), basic)
+	assert.NotContains(t, logs.String(), *client.Apitoken)
+}
@@ -0,0 +1,86 @@
+package itacservices_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-intelcloud/pkg/fakeitac"
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCredentialProcess(t *testing.T) {
+	ctx := context.Background()
+
+	creds, err := common.RunCredentialProcess(ctx, `echo '{"client_id": "id", "client_secret": "secret"}'`)
+	require.NoError(t, err)
+	assert.Equal(t, &common.ProcessCredentials{ClientID: "id", ClientSecret: "secret"}, creds)
+
+	creds, err = common.RunCredentialProcess(ctx, `echo '{"access_token": "token", "expires_at": "2030-01-02T15:04:05Z"}'`)
+	require.NoError(t, err)
+	assert.Equal(t, "token", creds.AccessToken)
+	assert.Equal(t, time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC), creds.ExpiresAt.UTC())
+
+	for _, tc := range []struct {
+		command string
+		err     string
+	}{
+		{`echo "vault is sealed" >&2; exit 2`, "exit status 2: vault is sealed"},
+		{`echo not json`, "error parsing credential process output"},
+		{`echo '{"client_id": "id"}'`, "client_id and client_secret together"},
+		{`echo '{"client_id": "id", "client_secret": "secret", "access_token": "token"}'`, "not both"},
+		{`echo '{}'`, "must set client_id and client_secret, or access_token"},
+	} {
+		_, err := common.RunCredentialProcess(ctx, tc.command)
+		require.Error(t, err, tc.command)
+		assert.Contains(t, err.Error(), tc.err, tc.command)
+	}
+}
+
+func TestNewClientWithCredentialProcess(t *testing.T) {
+	server := fakeitac.NewServer(t)
+	ctx := context.Background()
+
+	command := fmt.Sprintf(`echo '{"client_id": %q, "client_secret": %q}'`, server.ClientID, server.ClientSecret)
+	client, err := itacservices.NewClientWithCredentialProcess(ctx, strPtr(server.URL), strPtr(server.URL),
+		strPtr(server.CloudAccount), strPtr("us-region-1"), command, nil)
+	require.NoError(t, err)
+	assert.Equal(t, server.ClientID, *client.Clientid)
+
+	_, err = client.GetSSHKeys(ctx)
+	require.NoError(t, err)
+
+	_, err = itacservices.NewClientWithCredentialProcess(ctx, strPtr(server.URL), strPtr(server.URL),
+		strPtr(server.CloudAccount), strPtr("us-region-1"), `echo '{"client_id": "id", "client_secret": "wrong"}'`, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid client credentials")
+}
+
+func TestNewClientWithCredentialProcess_RenewsExpiredToken(t *testing.T) {
+	server := fakeitac.NewServer(t)
+	ctx := context.Background()
+
+	// the process counts its runs and hands out a token that is about to expire
+	runs := filepath.Join(t.TempDir(), "runs")
+	expiresAt := time.Now().Add(30 * time.Second).UTC().Format(time.RFC3339)
+	command := fmt.Sprintf(`echo run >> %s; echo '{"access_token": %q, "expires_at": %q}'`, runs, fakeitac.AccessToken, expiresAt)
+
+	client, err := itacservices.NewClientWithCredentialProcess(ctx, strPtr(server.URL), strPtr(server.URL),
+		strPtr(server.CloudAccount), strPtr("us-region-1"), command, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = client.GetSSHKeys(ctx)
+		require.NoError(t, err)
+	}
+	out, err := os.ReadFile(runs)
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(out), "run"))
+}
@@ -0,0 +1,60 @@
+package itacservices_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapHttpError(t *testing.T) {
+	err := common.MapHttpError(http.StatusNotFound, []byte(`{"code": 5, "message": "instance not found", "details": []}`))
+
+	var apiErr *common.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, 5, apiErr.Code)
+	assert.Equal(t, "instance not found", apiErr.Message)
+	assert.Equal(t, "Not Found, message: instance not found", err.Error())
+	assert.True(t, common.IsNotFound(err))
+	assert.True(t, errors.Is(fmt.Errorf("reading instance: %w", err), common.ErrNotFound))
+	assert.False(t, common.IsRetryable(err))
+}
+
+func TestMapHttpError_NotJSON(t *testing.T) {
+	err := common.MapHttpError(http.StatusBadGateway, []byte("<html>upstream error</html>\n"))
+
+	assert.Equal(t, "Bad Gateway, message: <html>upstream error</html>", err.Error())
+	assert.True(t, common.IsRetryable(err))
+}
+
+func TestAPIError_RequestID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1234")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"code": 6, "message": "sshkey already exists"}`))
+	}))
+	defer server.Close()
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr(server.URL),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+	}
+
+	_, err := client.CreateSSHkey(context.Background(), &itacservices.SSHKeyCreateRequest{})
+
+	require.Error(t, err)
+	var apiErr *common.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+	assert.Equal(t, "req-1234", apiErr.RequestID)
+	assert.Contains(t, err.Error(), "(request ID: req-1234)")
+}
@@ -0,0 +1,99 @@
+package itacservices_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"terraform-provider-intelcloud/pkg/fakeitac"
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFakeClient(t *testing.T) (*fakeitac.Server, *itacservices.IDCServicesClient) {
+	server := fakeitac.NewServer(t)
+	client, err := itacservices.NewClient(context.Background(), strPtr(server.URL), strPtr(server.URL),
+		strPtr(server.CloudAccount), strPtr(server.ClientID), strPtr(server.ClientSecret), strPtr("us-region-1"), nil)
+	require.NoError(t, err)
+	return server, client
+}
+
+func TestFakeITAC_InstancePhases(t *testing.T) {
+	_, client := newFakeClient(t)
+	ctx := context.Background()
+
+	in := &itacservices.InstanceCreateRequest{}
+	in.Metadata.Name = "vm-1"
+	instance, err := client.CreateInstance(ctx, in, true)
+	require.NoError(t, err)
+	assert.Equal(t, "Provisioning", instance.Status.Phase)
+
+	instance, err = client.GetInstanceByResourceId(ctx, instance.Metadata.ResourceId)
+	require.NoError(t, err)
+	assert.Equal(t, "Ready", instance.Status.Phase)
+
+	require.NoError(t, client.DeleteInstanceByResourceId(ctx, instance.Metadata.ResourceId))
+
+	deleting, err := client.GetInstanceByResourceId(ctx, instance.Metadata.ResourceId)
+	require.NoError(t, err)
+	assert.Equal(t, "Deleting", deleting.Status.Phase)
+
+	_, err = client.GetInstanceByResourceId(ctx, instance.Metadata.ResourceId)
+	assert.True(t, errors.Is(err, common.ErrNotFound))
+}
+
+func TestFakeITAC_ListPages(t *testing.T) {
+	server, client := newFakeClient(t)
+
+	// more keys than fit in one page
+	for i := 0; i < itacservices.DefaultPageSize+20; i++ {
+		_, err := server.Seed("sshpublickeys", map[string]any{"metadata": map[string]any{"name": fmt.Sprintf("key-%d", i)}})
+		require.NoError(t, err)
+	}
+
+	keys, err := client.GetSSHKeys(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, keys.SSHKey, itacservices.DefaultPageSize+20)
+}
+
+func TestFakeITAC_Faults(t *testing.T) {
+	server, client := newFakeClient(t)
+	server.InjectFault(fakeitac.Fault{Method: http.MethodGet, Path: "/vnets", Status: http.StatusServiceUnavailable, Message: "try later", Count: 1})
+
+	_, err := client.GetVNets(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "try later")
+
+	vnets, err := client.GetVNets(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, vnets.Vnets)
+}
+
+func TestFakeITAC_ProvisioningFailed(t *testing.T) {
+	server, client := newFakeClient(t)
+	server.TransitionReads = 0
+	server.InjectFault(fakeitac.Fault{Method: http.MethodPost, Path: "/instances", FailProvisioning: true, Message: "no capacity in zone"})
+
+	in := &itacservices.InstanceCreateRequest{}
+	in.Metadata.Name = "vm-1"
+	_, err := client.CreateInstance(context.Background(), in, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `last phase "Failed": no capacity in zone`)
+}
+
+func TestFakeITAC_WrappedErrors(t *testing.T) {
+	_, client := newFakeClient(t)
+
+	_, err := client.GetInstanceByResourceId(context.Background(), "missing-id")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error reading instance missing-id: ")
+	assert.True(t, errors.Is(err, common.ErrNotFound))
+
+	var apiErr *common.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
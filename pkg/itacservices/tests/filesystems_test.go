@@ -3,7 +3,9 @@ package itacservices_test
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
 	"terraform-provider-intelcloud/pkg/mocks"
 	"testing"
 
@@ -296,3 +298,61 @@ func TestDeleteFilesystemByResourceId_Success(t *testing.T) {
 	err := client.DeleteFilesystemByResourceId(ctx, resourceId)
 	assert.NoError(t, err)
 }
+
+func TestGetFilesystemByName_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(tmpl string, data interface{}) (string, error) {
+			return common.ParseString(tmpl, data)
+		})
+
+	mockAPI.EXPECT().
+		MakeGetAPICall(gomock.Any(), "https://example.com/v1/cloudaccounts/cloudacct-1/filesystems/name/my%20fs", "token", gomock.Nil()).
+		Return(http.StatusOK, []byte(`{"metadata": {"resourceId": "fs-1", "name": "my fs"}}`), nil)
+
+	fs, err := client.GetFilesystemByName(context.Background(), "my fs")
+
+	require.NoError(t, err)
+	assert.Equal(t, "fs-1", fs.Metadata.ResourceId)
+}
+
+func TestGetFilesystemByName_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return("https://example.com/v1/cloudaccounts/cloudacct-1/filesystems/name/missing", nil)
+
+	mockAPI.EXPECT().
+		MakeGetAPICall(gomock.Any(), gomock.Any(), "token", gomock.Nil()).
+		Return(http.StatusNotFound, []byte(`{"code": 5, "message": "filesystem not found"}`), nil)
+
+	fs, err := client.GetFilesystemByName(context.Background(), "missing")
+
+	require.Error(t, err)
+	assert.Nil(t, fs)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+	assert.Contains(t, err.Error(), "filesystem not found")
+}
@@ -0,0 +1,164 @@
+package itacservices_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+	"terraform-provider-intelcloud/pkg/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateFirewallRule_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	ctx := context.Background()
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return("https://example.com/v1/cloudaccounts/cloudacct-1/firewallrules?pageSize=100", nil).AnyTimes()
+
+	var sent itacservices.FirewallRuleCreateRequest
+	mockAPI.EXPECT().
+		MakePOSTAPICall(gomock.Any(), gomock.Any(), "token", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, payload []byte) (int, []byte, error) {
+			require.NoError(t, json.Unmarshal(payload, &sent))
+			return http.StatusOK, []byte(`{"metadata": {"resourceId": "fw-1", "name": "ssh"}}`), nil
+		})
+
+	mockAPI.EXPECT().
+		MakeGetAPICall(gomock.Any(), gomock.Any(), "token", gomock.Nil()).
+		Return(http.StatusOK, []byte(`{
+			"metadata": {"resourceId": "fw-1", "name": "ssh", "cloudAccountId": "cloudacct-1"},
+			"spec": {"vNet": "us-region-1a-default", "protocol": "TCP", "portStart": 22, "portEnd": 22, "sourceips": ["10.0.0.0/8"]},
+			"status": {"state": "Active"}
+		}`), nil)
+
+	in := &itacservices.FirewallRuleCreateRequest{
+		Spec: itacservices.FirewallRuleSpec{
+			VNet:      "us-region-1a-default",
+			Protocol:  "TCP",
+			PortStart: 22,
+			PortEnd:   22,
+			SourceIPs: []string{"10.0.0.0/8"},
+		},
+	}
+	in.Metadata.Name = "ssh"
+
+	rule, err := client.CreateFirewallRule(ctx, in)
+
+	require.NoError(t, err)
+	assert.Equal(t, "fw-1", rule.Metadata.ResourceID)
+	assert.Equal(t, "Active", rule.Status.State)
+	assert.Equal(t, []string{"10.0.0.0/8"}, rule.Spec.SourceIPs)
+	assert.Equal(t, "ssh", sent.Metadata.Name)
+	assert.Empty(t, sent.Spec.DestinationIP)
+}
+
+func TestCreateFirewallRule_Failed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	ctx := context.Background()
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return("https://example.com/v1/cloudaccounts/cloudacct-1/firewallrules?pageSize=100", nil).AnyTimes()
+
+	mockAPI.EXPECT().
+		MakePOSTAPICall(gomock.Any(), gomock.Any(), "token", gomock.Any()).
+		Return(http.StatusOK, []byte(`{"metadata": {"resourceId": "fw-1", "name": "ssh"}}`), nil)
+
+	mockAPI.EXPECT().
+		MakeGetAPICall(gomock.Any(), gomock.Any(), "token", gomock.Nil()).
+		Return(http.StatusOK, []byte(`{"metadata": {"resourceId": "fw-1"}, "status": {"state": "Failed", "message": "vip not found"}}`), nil)
+
+	_, err := client.CreateFirewallRule(ctx, &itacservices.FirewallRuleCreateRequest{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vip not found")
+}
+
+func TestGetFirewallRules_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return("https://example.com/v1/cloudaccounts/cloudacct-1/firewallrules?pageSize=100", nil)
+
+	mockAPI.EXPECT().
+		MakeGetAPICall(gomock.Any(), "https://example.com/v1/cloudaccounts/cloudacct-1/firewallrules?pageSize=100", "token", gomock.Nil()).
+		Return(http.StatusOK, []byte(`{"items": [
+			{"metadata": {"resourceId": "fw-1", "name": "ssh"}},
+			{"metadata": {"resourceId": "fw-2", "name": "https"}}
+		]}`), nil)
+
+	rules, err := client.GetFirewallRules(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, rules.FirewallRules, 2)
+	assert.Equal(t, "fw-2", rules.FirewallRules[1].Metadata.ResourceID)
+	assert.Equal(t, "https", rules.FirewallRules[1].Metadata.Name)
+}
+
+func TestGetFirewallRuleByID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return("https://example.com/v1/cloudaccounts/cloudacct-1/firewallrules/id/fw-1", nil)
+
+	mockAPI.EXPECT().
+		MakeGetAPICall(gomock.Any(), "https://example.com/v1/cloudaccounts/cloudacct-1/firewallrules/id/fw-1", "token", gomock.Nil()).
+		Return(http.StatusNotFound, []byte(`{"code": 5, "message": "firewall rule not found"}`), nil)
+
+	_, err := client.GetFirewallRuleByID(context.Background(), "fw-1")
+
+	require.Error(t, err)
+	assert.True(t, common.IsNotFound(err))
+	assert.Contains(t, err.Error(), "error reading firewall rule fw-1")
+}
@@ -0,0 +1,105 @@
+package itacservices_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeAPICall_RetryResendsBody(t *testing.T) {
+	var attempts int32
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, string(body))
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			// drop the connection without a response so the call is retried
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	payload := []byte(`{"metadata": {"name": "vm-1"}}`)
+	retcode, _, err := common.MakePOSTAPICall(context.Background(), server.Client(), server.URL, "token", payload)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, retcode)
+	assert.Equal(t, []string{string(payload), string(payload)}, received)
+}
+
+func TestMakeAPICall_PoolsConnections(t *testing.T) {
+	var conns int32
+	server := httptest.NewUnstartedServer(okHandler(`{}`))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	httpClient, err := common.NewHTTPClient(common.TransportConfig{})
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		retcode, _, err := common.MakeGetAPICall(context.Background(), httpClient, server.URL, "token", nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, retcode)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&conns))
+}
+
+func TestMakeAPICall_ResponseHeaderTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	httpClient, err := common.NewHTTPClient(common.TransportConfig{ResponseHeaderTimeout: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	// cancel the context so the failed call is not retried
+	ctx, cancel := context.WithCancel(context.Background())
+	transport := httpClient.Transport
+	httpClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := transport.RoundTrip(req)
+		cancel()
+		return resp, err
+	})
+
+	_, _, err = common.MakeGetAPICall(ctx, httpClient, server.URL, "token", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timeout awaiting response headers")
+}
+
+func TestNewHTTPClient_RequestTimeout(t *testing.T) {
+	// the whole request gets the step timeouts plus time to read the body
+	httpClient, err := common.NewHTTPClient(common.TransportConfig{ResponseHeaderTimeout: 5 * time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, common.DefaultDialTimeout+common.DefaultTLSHandshakeTimeout+5*time.Minute+common.DefaultBodyReadTimeout, httpClient.Timeout)
+
+	httpClient, err = common.NewHTTPClient(common.TransportConfig{ResponseHeaderTimeout: 5 * time.Minute, RequestTimeout: 10 * time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, httpClient.Timeout)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"terraform-provider-intelcloud/pkg/itacservices"
 	"terraform-provider-intelcloud/pkg/itacservices/common"
 	"terraform-provider-intelcloud/pkg/mocks"
@@ -127,7 +128,7 @@ func TestGetKubernetesClusters_Success(t *testing.T) {
 
 	// Mock MakeGetAPICall call
 	mockAPI.EXPECT().
-		MakeGetAPICall(ctx, expectedURL, "token", gomock.Nil()).
+		MakeGetAPICall(ctx, expectedURL+"?pageSize=100", "token", gomock.Nil()).
 		Return(http.StatusOK, []byte(`{
 		    "clusters": [
         {
@@ -230,3 +231,270 @@ func TestCreateIKSCluster_Success(t *testing.T) {
 	assert.Equal(t, "cloudacct-1", *cloudAccount)
 	assert.Equal(t, "Active", cluster.ClusterState)
 }
+
+func TestGetIKSK8sVersions_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	ctx := context.Background()
+	expectedURL := "https://example.com/v1/cloudaccounts/cloudacct-1/iks/metadata/k8sversions"
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return(expectedURL, nil)
+
+	mockAPI.EXPECT().
+		MakeGetAPICall(ctx, expectedURL, "token", gomock.Nil()).
+		Return(http.StatusOK, []byte(`{
+			"k8sversions": [
+				{"k8sversionname": "1.29", "runtimename": "Containerd"},
+				{"k8sversionname": "1.30", "runtimename": "Containerd"}
+			]
+		}`), nil)
+
+	versions, err := client.GetIKSK8sVersions(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, versions.K8sVersions, 2)
+	assert.Equal(t, "1.29", versions.K8sVersions[0].K8sVersion)
+	assert.Equal(t, "Containerd", versions.K8sVersions[1].RuntimeName)
+}
+
+func TestUpdateNodeGroup_SendsLabelsAndTaints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	// node group reads go through the real http client; the first one still shows the old
+	// spec, so the update only completes once the second one shows the requested change
+	// next to the labels and annotations the service adds
+	reads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reads++
+		w.WriteHeader(http.StatusOK)
+		if reads == 1 {
+			_, _ = w.Write([]byte(`{"nodegroupuuid": "ng-1", "clusteruuid": "iks-1", "nodegroupstate": "Active",
+				"count": 1, "nodes": [{"name": "node-1"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"nodegroupuuid": "ng-1", "clusteruuid": "iks-1", "nodegroupstate": "Active",
+			"count": 2, "nodes": [{"name": "node-1"}, {"name": "node-2"}],
+			"labels": {"accelerator": "gaudi", "iks.intel.com/nodegroup": "ng-1"},
+			"annotations": {"iks.intel.com/managed": "true"},
+			"taints": [{"key": "habana.ai/gaudi", "value": "", "effect": "NoSchedule"}]}`))
+	}))
+	defer server.Close()
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	ctx := context.Background()
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return(server.URL, nil).AnyTimes()
+
+	var sent itacservices.UpdateNodeGroupPayload
+	mockAPI.EXPECT().
+		MakePutAPICall(gomock.Any(), server.URL, "token", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, payload []byte) (int, []byte, error) {
+			require.NoError(t, json.Unmarshal(payload, &sent))
+			return http.StatusOK, []byte(`{}`), nil
+		})
+
+	err := client.UpdateNodeGroup(ctx, &itacservices.UpdateNodeGroupRequest{
+		ClusterId:   "iks-1",
+		NodeGroupId: "ng-1",
+		Count:       2,
+		Labels:      map[string]string{"accelerator": "gaudi"},
+		Taints:      []itacservices.Taint{{Key: "habana.ai/gaudi", Effect: "NoSchedule"}},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, sent.Count)
+	assert.Equal(t, int64(2), *sent.Count)
+	assert.Equal(t, "gaudi", sent.Labels["accelerator"])
+	assert.NotNil(t, sent.Annotations)
+	require.Len(t, sent.Taints, 1)
+	assert.Equal(t, "NoSchedule", sent.Taints[0].Effect)
+	assert.Equal(t, 2, reads)
+}
+
+func TestUpdateNodeGroup_AutoscalingLeavesCountOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	// the autoscaler has grown the node group beyond the count in the configuration
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"nodegroupuuid": "ng-1", "clusteruuid": "iks-1", "nodegroupstate": "Active",
+			"count": 4, "nodes": [{"name": "node-1"}, {"name": "node-2"}, {"name": "node-3"}, {"name": "node-4"}],
+			"autoscaling": {"enabled": true, "mincount": 1, "maxcount": 5}}`))
+	}))
+	defer server.Close()
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return(server.URL, nil).AnyTimes()
+
+	var payload map[string]any
+	mockAPI.EXPECT().
+		MakePutAPICall(gomock.Any(), server.URL, "token", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, body []byte) (int, []byte, error) {
+			require.NoError(t, json.Unmarshal(body, &payload))
+			return http.StatusOK, []byte(`{}`), nil
+		})
+
+	err := client.UpdateNodeGroup(context.Background(), &itacservices.UpdateNodeGroupRequest{
+		ClusterId:   "iks-1",
+		NodeGroupId: "ng-1",
+		Count:       2,
+		Autoscaling: &itacservices.NodeGroupAutoscaling{Enabled: true, MinCount: 1, MaxCount: 5},
+	})
+
+	require.NoError(t, err)
+	assert.NotContains(t, payload, "count")
+	assert.Contains(t, payload, "autoscaling")
+}
+
+func TestUpgradeNodeGroup_WaitsForNewImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	// the read before the upgrade and the first one after it still show the old image
+	reads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reads++
+		w.WriteHeader(http.StatusOK)
+		if reads <= 2 {
+			_, _ = w.Write([]byte(`{"nodegroupuuid": "ng-1", "clusteruuid": "iks-1", "nodegroupstate": "Active",
+				"imiid": "imi-old", "upgradeavailable": true, "upgradeimiid": "imi-new"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"nodegroupuuid": "ng-1", "clusteruuid": "iks-1", "nodegroupstate": "Active",
+			"imiid": "imi-new", "upgradeavailable": false}`))
+	}))
+	defer server.Close()
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return(server.URL, nil).AnyTimes()
+	mockAPI.EXPECT().
+		MakePOSTAPICall(gomock.Any(), server.URL, "token", gomock.Any()).
+		Return(http.StatusOK, []byte(`{}`), nil)
+
+	err := client.UpgradeNodeGroup(context.Background(), &itacservices.UpgradeNodeGroupRequest{
+		ClusterId:   "iks-1",
+		NodeGroupId: "ng-1",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, reads)
+}
+
+func TestUpgradeNodeGroup_RejectsUnavailableImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"nodegroupuuid": "ng-1", "clusteruuid": "iks-1", "nodegroupstate": "Active",
+			"imiid": "imi-old", "upgradeavailable": true, "upgradeimiid": "imi-new"}`))
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return(server.URL, nil).AnyTimes()
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	err := client.UpgradeNodeGroup(context.Background(), &itacservices.UpgradeNodeGroupRequest{
+		ClusterId:   "iks-1",
+		NodeGroupId: "ng-1",
+		IMIID:       "imi-other",
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "imiid imi-other is not available")
+}
+
+func TestCreateIKSLoadBalancer_ListenerFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	// load balancer reads go through the real http client
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"metadata": {"resourceId": "lb-1", "name": "web"},
+			"status": {
+				"state": "Pending",
+				"listeners": [{"port": 80, "state": "Failed", "message": "vip quota exceeded"}]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	ctx := context.Background()
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return(server.URL, nil).AnyTimes()
+
+	mockAPI.EXPECT().
+		MakePOSTAPICall(gomock.Any(), server.URL, "token", gomock.Any()).
+		Return(http.StatusOK, []byte(`{"metadata": {"resourceId": "lb-1", "name": "web"}}`), nil)
+
+	_, _, err := client.CreateIKSLoadBalancer(ctx, &itacservices.IKSLoadbalancerCreateRequest{}, "iks-1")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vip quota exceeded")
+}
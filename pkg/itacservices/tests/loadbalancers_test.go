@@ -0,0 +1,134 @@
+package itacservices_test
+
+import (
+	"context"
+	"net/http"
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateLoadBalancer_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	ctx := context.Background()
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return("https://example.com/v1/cloudaccounts/cloudacct-1/loadbalancers", nil).AnyTimes()
+
+	mockAPI.EXPECT().
+		MakePOSTAPICall(gomock.Any(), gomock.Any(), "token", gomock.Any()).
+		Return(http.StatusOK, []byte(`{"metadata": {"resourceId": "lb-1", "name": "web"}}`), nil)
+
+	mockAPI.EXPECT().
+		MakeGetAPICall(gomock.Any(), gomock.Any(), "token", gomock.Nil()).
+		Return(http.StatusOK, []byte(`{
+			"metadata": {"resourceId": "lb-1", "name": "web", "cloudAccountId": "cloudacct-1"},
+			"spec": {
+				"listeners": [{"port": 80, "pool": {"port": 8080, "monitor": "tcp", "instanceResourceIds": ["inst-1"]}}],
+				"security": {"sourceips": ["any"]}
+			},
+			"status": {
+				"state": "Active",
+				"vip": "10.0.0.10",
+				"listeners": [{"port": 80, "state": "Active", "vipId": 7, "poolId": 9,
+					"poolMembers": [{"instanceRef": "inst-1", "ip": "10.0.1.5"}]}]
+			}
+		}`), nil)
+
+	in := &itacservices.LoadBalancerCreateRequest{}
+	in.Metadata.Name = "web"
+
+	lb, err := client.CreateLoadBalancer(ctx, in)
+
+	require.NoError(t, err)
+	assert.Equal(t, "lb-1", lb.Metadata.ResourceID)
+	assert.Equal(t, "10.0.0.10", lb.Status.VIP)
+	require.Len(t, lb.Status.Listeners, 1)
+	assert.Equal(t, "10.0.1.5", lb.Status.Listeners[0].PoolMembers[0].IP)
+	assert.Equal(t, []string{"inst-1"}, lb.Spec.Listeners[0].Pool.InstanceResourceIds)
+}
+
+func TestCreateLoadBalancer_ListenerFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	ctx := context.Background()
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return("https://example.com/v1/cloudaccounts/cloudacct-1/loadbalancers", nil).AnyTimes()
+
+	mockAPI.EXPECT().
+		MakePOSTAPICall(gomock.Any(), gomock.Any(), "token", gomock.Any()).
+		Return(http.StatusOK, []byte(`{"metadata": {"resourceId": "lb-1", "name": "web"}}`), nil)
+
+	mockAPI.EXPECT().
+		MakeGetAPICall(gomock.Any(), gomock.Any(), "token", gomock.Nil()).
+		Return(http.StatusOK, []byte(`{
+			"metadata": {"resourceId": "lb-1", "name": "web"},
+			"status": {
+				"state": "Pending",
+				"listeners": [{"port": 80, "state": "Failed", "message": "no pool members found"}]
+			}
+		}`), nil)
+
+	in := &itacservices.LoadBalancerCreateRequest{}
+	in.Metadata.Name = "web"
+
+	_, err := client.CreateLoadBalancer(ctx, in)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no pool members found")
+}
+
+func TestGetLoadBalancers_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return("https://example.com/v1/cloudaccounts/cloudacct-1/loadbalancers", nil)
+
+	mockAPI.EXPECT().
+		MakeGetAPICall(gomock.Any(), gomock.Any(), "token", gomock.Nil()).
+		Return(http.StatusInternalServerError, []byte(`{"code": 13, "message": "internal error"}`), nil)
+
+	lbs, err := client.GetLoadBalancers(context.Background())
+
+	require.Error(t, err)
+	assert.Nil(t, lbs)
+}
@@ -0,0 +1,106 @@
+package itacservices_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateObjectBucketSecurityGroup_Success(t *testing.T) {
+	var sent itacservices.ObjectBucketSecurityGroupUpdateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			assert.Equal(t, "/v1/cloudaccounts/cloudacct-1/objects/buckets/id/bucket-1/securitygroup", r.URL.Path)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&sent))
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{}`))
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{
+				"metadata": {"resourceId": "bucket-1", "name": "data"},
+				"status": {
+					"phase": "BucketReady",
+					"securityGroup": {"networkFilterAllow": [{"gateway": "10.0.0.1", "prefixLength": 24, "subnet": "10.0.0.0"}]}
+				}
+			}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr(server.URL),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+	}
+
+	bucket, err := client.UpdateObjectBucketSecurityGroup(context.Background(), "bucket-1", []itacservices.NetworkFilter{
+		{Gateway: "10.0.0.1", PrefixLength: 24, Subnet: "10.0.0.0"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, sent.NetworkFilterAllow, 1)
+	assert.Equal(t, "10.0.0.0", sent.NetworkFilterAllow[0].Subnet)
+	require.Len(t, bucket.Status.SecurityGroups.NetworkFilterAllow, 1)
+	assert.Equal(t, 24, bucket.Status.SecurityGroups.NetworkFilterAllow[0].PrefixLength)
+}
+
+func TestGetObjectBucketsAndUsers_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/v1/cloudaccounts/cloudacct-1/objects/buckets":
+			_, _ = w.Write([]byte(`{"buckets": [{"metadata": {"resourceId": "bucket-1", "name": "cloudacct-1-data"}}]}`))
+		case "/v1/cloudaccounts/cloudacct-1/objects/users":
+			_, _ = w.Write([]byte(`{"users": [{"metadata": {"userId": "user-1", "name": "reader"}}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr(server.URL),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+	}
+
+	buckets, err := client.GetObjectBuckets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, buckets.Buckets, 1)
+	assert.Equal(t, "bucket-1", buckets.Buckets[0].Metadata.ResourceId)
+
+	users, err := client.GetObjectUsers(context.Background())
+	require.NoError(t, err)
+	require.Len(t, users.Users, 1)
+	assert.Equal(t, "user-1", users.Users[0].Metadata.UserId)
+}
+
+func TestGetObjectBucketByName_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/cloudaccounts/cloudacct-1/objects/buckets/name/data", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": 5, "message": "bucket not found"}`))
+	}))
+	defer server.Close()
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr(server.URL),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+	}
+
+	bucket, err := client.GetObjectBucketByName(context.Background(), "data")
+
+	require.Error(t, err)
+	assert.Nil(t, bucket)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+}
@@ -0,0 +1,107 @@
+package itacservices_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSSHKeys_FollowsPageTokens(t *testing.T) {
+	pages := map[string]string{
+		"":       `{"items": [{"metadata": {"name": "key-1"}}], "nextPageToken": "page-2"}`,
+		"page-2": `{"items": [{"metadata": {"name": "key-2"}}], "nextPageToken": "page-3"}`,
+		"page-3": `{"items": [{"metadata": {"name": "key-3"}}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "100", r.URL.Query().Get("pageSize"))
+		body, ok := pages[r.URL.Query().Get("pageToken")]
+		require.True(t, ok)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr(server.URL),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+	}
+
+	keys, err := client.GetSSHKeys(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, keys.SSHKey, 3)
+	assert.Equal(t, "key-1", keys.SSHKey[0].Metadata.Name)
+	assert.Equal(t, "key-3", keys.SSHKey[2].Metadata.Name)
+	assert.Empty(t, keys.NextPageToken)
+}
+
+func TestGetFilesystems_KeepsQueryWhilePaging(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	listURL := "https://example.com/v1/cloudaccounts/cloudacct-1/filesystems?metadata.filterType=ComputeGeneral"
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return(listURL, nil)
+
+	gomock.InOrder(
+		mockAPI.EXPECT().
+			MakeGetAPICall(gomock.Any(), listURL+"&pageSize=100", "token", gomock.Nil()).
+			Return(http.StatusOK, []byte(`{"items": [], "nextPageToken": "next"}`), nil),
+		mockAPI.EXPECT().
+			MakeGetAPICall(gomock.Any(), listURL+"&pageSize=100&pageToken=next", "token", gomock.Nil()).
+			Return(http.StatusOK, []byte(`{"items": []}`), nil),
+	)
+
+	filesystems, err := client.GetFilesystems(context.Background())
+
+	require.NoError(t, err)
+	assert.Empty(t, filesystems.FilesystemList)
+}
+
+func TestGetLoadBalancers_RepeatedPageToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		Return("https://example.com/v1/cloudaccounts/cloudacct-1/loadbalancers", nil)
+
+	mockAPI.EXPECT().
+		MakeGetAPICall(gomock.Any(), gomock.Any(), "token", gomock.Nil()).
+		Return(http.StatusOK, []byte(`{"items": [{"metadata": {"name": "lb-1"}}], "nextPageToken": "same"}`), nil).
+		Times(2)
+
+	lbs, err := client.GetLoadBalancers(context.Background())
+
+	require.Error(t, err)
+	assert.Nil(t, lbs)
+	assert.Contains(t, err.Error(), "returned twice")
+}
@@ -0,0 +1,72 @@
+package itacservices_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeProfileFile(t *testing.T, content string) string {
+	file := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	return file
+}
+
+func TestLoadProfile(t *testing.T) {
+	file := writeProfileFile(t, `
+; comments are skipped
+[default]
+region = us-region-1
+
+[profile dev]
+region        = us-region-2
+cloudaccount  = 123456789012
+client_id     = dev-id
+client_secret = "dev=secret"
+api_endpoint  = https://api.example.com
+auth_endpoint = https://auth.example.com
+
+[other]
+region  = us-region-3
+sso_url = https://sso.example.com
+output  = json
+`)
+
+	profile, err := common.LoadProfile(file, "dev")
+	require.NoError(t, err)
+	assert.Equal(t, &common.Profile{
+		Name:         "dev",
+		Region:       "us-region-2",
+		Cloudaccount: "123456789012",
+		ClientID:     "dev-id",
+		ClientSecret: "dev=secret",
+		APIEndpoint:  "https://api.example.com",
+		AuthEndpoint: "https://auth.example.com",
+	}, profile)
+
+	profile, err = common.LoadProfile(file, common.DefaultProfileName)
+	require.NoError(t, err)
+	assert.Equal(t, &common.Profile{Name: "default", Region: "us-region-1"}, profile)
+
+	_, err = common.LoadProfile(file, "prod")
+	assert.True(t, errors.Is(err, common.ErrProfileNotFound))
+
+	profile, err = common.LoadProfile(file, "other")
+	require.NoError(t, err)
+	assert.Equal(t, &common.Profile{Name: "other", Region: "us-region-3", Ignored: []string{"sso_url", "output"}}, profile)
+}
+
+func TestLoadProfile_Invalid(t *testing.T) {
+	_, err := common.LoadProfile(filepath.Join(t.TempDir(), "missing"), "default")
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	file := writeProfileFile(t, "[default]\nregion us-region-1\n")
+	_, err = common.LoadProfile(file, "default")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2 is not a key = value setting")
+}
@@ -0,0 +1,100 @@
+package itacservices_test
+
+import (
+	"os"
+	"path/filepath"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegionCatalog_Builtin(t *testing.T) {
+	catalog := common.DefaultRegionCatalog()
+	assert.Equal(t, []string{"us-region-1", "us-region-2", "us-region-3", "us-region-4", "us-region-5"}, catalog.Names())
+
+	region, err := catalog.Region("us-region-2")
+	require.NoError(t, err)
+	assert.Equal(t, "https://us-region-2-sdk-api.cloud.intel.com", region.APIEndpoint)
+	assert.Equal(t, "https://client-token.api.idcservice.net", region.AuthEndpoint)
+	assert.Equal(t, "us-region-2a", region.DefaultZone())
+
+	_, err = catalog.Region("eu-region-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown region "eu-region-1", expected one of: us-region-1, us-region-2`)
+}
+
+func TestRegion_ValidateZone(t *testing.T) {
+	region, err := common.DefaultRegionCatalog().Region("us-region-1")
+	require.NoError(t, err)
+
+	assert.NoError(t, region.ValidateZone("us-region-1a"))
+	// built-in regions accept the zones named after them, not only the listed default zone
+	assert.NoError(t, region.ValidateZone("us-region-1b"))
+	assert.Error(t, region.ValidateZone("us-region-2a"))
+	assert.Error(t, region.ValidateZone("us-region-1"))
+	assert.Error(t, region.ValidateZone("us-region-10a"))
+}
+
+func TestLoadRegionCatalog_Overrides(t *testing.T) {
+	yamlFile := filepath.Join(t.TempDir(), "regions.yaml")
+	require.NoError(t, os.WriteFile(yamlFile, []byte(`
+regions:
+  - name: us-region-2
+    api: https://us-region-2.example.internal
+    auth: https://token.example.internal
+    zones: [us-region-2b, us-region-2a]
+  - name: eu-region-1
+    api: https://eu-region-1.example.internal
+    auth: https://token.example.internal
+    zones: [eu-region-1a]
+`), 0o600))
+
+	jsonFile := filepath.Join(t.TempDir(), "regions.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"regions": [
+		{"name": "eu-region-1", "api": "https://eu-region-1.example.internal", "auth": "https://token.example.internal", "zones": ["eu-region-1a"]}
+	]}`), 0o600))
+
+	for name, source := range map[string]string{
+		"yaml file":   yamlFile,
+		"json file":   jsonFile,
+		"inline json": `{"regions": [{"name": "eu-region-1", "api": "https://eu-region-1.example.internal", "auth": "https://token.example.internal", "zones": ["eu-region-1a"]}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			catalog, err := common.LoadRegionCatalog(source)
+			require.NoError(t, err)
+			assert.Contains(t, catalog.Names(), "us-region-1")
+
+			region, err := catalog.Region("eu-region-1")
+			require.NoError(t, err)
+			assert.Equal(t, "https://eu-region-1.example.internal", region.APIEndpoint)
+			assert.Equal(t, "eu-region-1a", region.DefaultZone())
+		})
+	}
+
+	// a region of the file replaces the built-in one
+	catalog, err := common.LoadRegionCatalog(yamlFile)
+	require.NoError(t, err)
+	region, err := catalog.Region("us-region-2")
+	require.NoError(t, err)
+	assert.Equal(t, "https://us-region-2.example.internal", region.APIEndpoint)
+	assert.Equal(t, "us-region-2b", region.DefaultZone())
+	assert.NoError(t, region.ValidateZone("us-region-2a"))
+	// catalog file regions only accept the zones they list
+	assert.Error(t, region.ValidateZone("us-region-2c"))
+}
+
+func TestLoadRegionCatalog_Invalid(t *testing.T) {
+	_, err := common.LoadRegionCatalog(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error reading region catalog")
+
+	_, err = common.LoadRegionCatalog(`{"regions": [{"name": "eu-region-1"}]}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must set name, api, auth and zones")
+
+	_, err = common.LoadRegionCatalog(`{"regions": `)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error parsing region catalog")
+}
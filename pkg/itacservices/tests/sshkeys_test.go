@@ -0,0 +1,89 @@
+package itacservices_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+	"terraform-provider-intelcloud/pkg/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSSHKeyPair(t *testing.T) {
+	tests := []struct {
+		keyType string
+		prefix  string
+	}{
+		{keyType: common.SSHKeyTypeED25519, prefix: "ssh-ed25519 "},
+		{keyType: common.SSHKeyTypeRSA, prefix: "ssh-rsa "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.keyType, func(t *testing.T) {
+			publicKey, privateKey, err := common.GenerateSSHKeyPair(tt.keyType)
+
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(publicKey, tt.prefix))
+			assert.Contains(t, privateKey, "BEGIN OPENSSH PRIVATE KEY")
+
+			fingerprint, err := common.SSHKeyFingerprint(publicKey)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(fingerprint, "SHA256:"))
+		})
+	}
+}
+
+func TestGenerateSSHKeyPair_UnsupportedType(t *testing.T) {
+	_, _, err := common.GenerateSSHKeyPair("dsa")
+	assert.Error(t, err)
+}
+
+func TestSSHKeyFingerprint(t *testing.T) {
+	publicKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl user@host"
+
+	fingerprint, err := common.SSHKeyFingerprint(publicKey)
+	require.NoError(t, err)
+
+	// trailing whitespace and comments do not change the fingerprint
+	normalized, err := common.SSHKeyFingerprint(strings.Fields(publicKey)[0] + " " + strings.Fields(publicKey)[1] + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, normalized)
+
+	_, err = common.SSHKeyFingerprint("not a key")
+	assert.Error(t, err)
+}
+
+func TestGetSSHKeyByName_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockAPI := mocks.NewMockAPIClient(ctrl)
+
+	client := &itacservices.IDCServicesClient{
+		Host:         strPtr("https://example.com"),
+		Cloudaccount: strPtr("cloudacct-1"),
+		Apitoken:     strPtr("token"),
+		APIClient:    mockAPI,
+	}
+
+	mockAPI.EXPECT().
+		ParseString(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(tmpl string, data interface{}) (string, error) {
+			return common.ParseString(tmpl, data)
+		})
+
+	mockAPI.EXPECT().
+		MakeGetAPICall(gomock.Any(), "https://example.com/v1/cloudaccounts/cloudacct-1/sshpublickeys/name/deploy", "token", gomock.Nil()).
+		Return(http.StatusOK, []byte(`{"metadata": {"resourceId": "key-1", "name": "deploy"}, "spec": {"sshPublicKey": "ssh-ed25519 AAAA"}}`), nil)
+
+	key, err := client.GetSSHKeyByName(context.Background(), "deploy")
+
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", key.Metadata.ResourceId)
+	assert.Equal(t, "ssh-ed25519 AAAA", key.Spec.SSHPublicKey)
+}
@@ -0,0 +1,153 @@
+package itacservices_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func okHandler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func serverCAPEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+// selfSignedPEM returns a throwaway certificate and key for client authentication.
+func selfSignedPEM(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestNewTransport_CustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(okHandler(`{}`))
+	defer server.Close()
+	ctx := context.Background()
+
+	// the test server certificate is not in the system roots
+	transport, err := common.NewTransport(common.TransportConfig{})
+	require.NoError(t, err)
+	_, err = (&http.Client{Transport: transport}).Get(server.URL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate")
+
+	transport, err = common.NewTransport(common.TransportConfig{CACertPEM: serverCAPEM(server)})
+	require.NoError(t, err)
+	retcode, _, err := common.MakeGetAPICall(ctx, &http.Client{Transport: transport}, server.URL, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, retcode)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte(serverCAPEM(server)), 0o600))
+	transport, err = common.NewTransport(common.TransportConfig{CACertFile: caFile})
+	require.NoError(t, err)
+	retcode, _, err = common.MakeGetAPICall(ctx, &http.Client{Transport: transport}, server.URL, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, retcode)
+
+	transport, err = common.NewTransport(common.TransportConfig{InsecureSkipVerify: true})
+	require.NoError(t, err)
+	retcode, _, err = common.MakeGetAPICall(ctx, &http.Client{Transport: transport}, server.URL, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, retcode)
+}
+
+func TestNewTransport_InvalidCA(t *testing.T) {
+	_, err := common.NewTransport(common.TransportConfig{CACertPEM: "not a certificate"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no certificates found")
+
+	_, err = common.NewTransport(common.TransportConfig{CACertFile: filepath.Join(t.TempDir(), "missing.pem")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error reading ca certificate file")
+}
+
+func TestNewTransport_ClientCertificate(t *testing.T) {
+	var presented int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented = len(r.TLS.PeerCertificates)
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	certPEM, keyPEM := selfSignedPEM(t)
+	keyFile := filepath.Join(t.TempDir(), "client.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(keyPEM), 0o600))
+
+	// the certificate is given inline and the key as a path
+	transport, err := common.NewTransport(common.TransportConfig{
+		CACertPEM:  serverCAPEM(server),
+		ClientCert: certPEM,
+		ClientKey:  keyFile,
+	})
+	require.NoError(t, err)
+	retcode, _, err := common.MakeGetAPICall(context.Background(), &http.Client{Transport: transport}, server.URL, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, retcode)
+	assert.Equal(t, 1, presented)
+
+	_, err = common.NewTransport(common.TransportConfig{ClientCert: certPEM})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be set together")
+}
+
+func TestNewTransport_Proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a forward proxy receives the absolute url of the target
+		proxied = append(proxied, r.URL.String())
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"via": "proxy"}`))
+	}))
+	defer proxy.Close()
+
+	target := "http://itac.example.internal/v1/cloudaccounts"
+	transport, err := common.NewTransport(common.TransportConfig{ProxyURL: proxy.URL})
+	require.NoError(t, err)
+	retcode, body, err := common.MakeGetAPICall(context.Background(), &http.Client{Transport: transport}, target, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, retcode)
+	assert.JSONEq(t, `{"via": "proxy"}`, string(body))
+	assert.Equal(t, []string{target}, proxied)
+
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	require.NoError(t, err)
+	transport, err = common.NewTransport(common.TransportConfig{ProxyURL: proxy.URL, NoProxy: ".example.internal"})
+	require.NoError(t, err)
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	assert.Nil(t, proxyURL)
+}
@@ -0,0 +1,17 @@
+package itacservices_test
+
+import (
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAvailabilityZone(t *testing.T) {
+	assert.NoError(t, common.ValidateAvailabilityZone("us-region-1", "us-region-1a"))
+	assert.NoError(t, common.ValidateAvailabilityZone("us-region-2", "us-region-2b"))
+
+	assert.Error(t, common.ValidateAvailabilityZone("us-region-1", "us-region-2a"))
+	assert.Error(t, common.ValidateAvailabilityZone("us-region-1", "us-region-1"))
+	assert.Error(t, common.ValidateAvailabilityZone("us-region-1", "us-region-10a"))
+}
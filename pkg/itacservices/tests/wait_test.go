@@ -0,0 +1,145 @@
+package itacservices_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"terraform-provider-intelcloud/pkg/itacservices"
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type waitObj struct {
+	state string
+}
+
+// sequenceWaiter returns a waiter whose reads walk through states, repeating the last one.
+func sequenceWaiter(states ...string) (*itacservices.Waiter[waitObj], *int) {
+	reads := 0
+	return &itacservices.Waiter[waitObj]{
+		Noun: "widget",
+		ID:   "w-1",
+		Refresh: func(ctx context.Context) (*waitObj, string, error) {
+			state := states[min(reads, len(states)-1)]
+			reads++
+			switch state {
+			case "gone":
+				return nil, "", fmt.Errorf("error reading widget w-1: %w", common.ErrNotFound)
+			case "unavailable":
+				return nil, "", fmt.Errorf("error reading widget w-1: %w", common.MapHttpError(http.StatusServiceUnavailable, nil))
+			case "forbidden":
+				return nil, "", fmt.Errorf("error reading widget w-1: %w", common.MapHttpError(http.StatusForbidden, nil))
+			}
+			return &waitObj{state: state}, state, nil
+		},
+		Message:     func(o *waitObj) string { return "" },
+		Pending:     []string{"Provisioning"},
+		Target:      []string{"Ready"},
+		Failure:     []string{"Failed"},
+		MinInterval: time.Millisecond,
+		MaxInterval: 4 * time.Millisecond,
+	}, &reads
+}
+
+func TestWaiter_Target(t *testing.T) {
+	w, reads := sequenceWaiter("Provisioning", "Provisioning", "Ready")
+	obj, err := w.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Ready", obj.state)
+	assert.Equal(t, 3, *reads)
+}
+
+func TestWaiter_MinTargetReads(t *testing.T) {
+	w, reads := sequenceWaiter("Ready", "Provisioning", "Ready")
+	w.MinTargetReads = 2
+	_, err := w.Wait(context.Background())
+	require.NoError(t, err)
+	// the first Ready read is not confirmed by the next one, so the count restarts
+	assert.Equal(t, 4, *reads)
+}
+
+func TestWaiter_Done(t *testing.T) {
+	w, reads := sequenceWaiter("Ready")
+	// the change only shows from the third read on, the earlier Ready reads keep the wait going
+	w.Done = func(o *waitObj) bool { return *reads >= 3 }
+	_, err := w.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, *reads)
+}
+
+func TestWaiter_RetryableReadError(t *testing.T) {
+	w, reads := sequenceWaiter("Provisioning", "unavailable", "unavailable", "Ready")
+	obj, err := w.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Ready", obj.state)
+	assert.Equal(t, 4, *reads)
+
+	// other read errors stop the wait
+	w, reads = sequenceWaiter("Provisioning", "forbidden", "Ready")
+	_, err = w.Wait(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Forbidden")
+	assert.Equal(t, 2, *reads)
+}
+
+func TestWaiter_Failure(t *testing.T) {
+	w, _ := sequenceWaiter("Provisioning", "Failed")
+	w.Message = func(o *waitObj) string { return "no capacity" }
+	obj, err := w.Wait(context.Background())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, itacservices.ErrWaitFailed))
+	assert.Equal(t, "Failed", obj.state)
+	assert.Contains(t, err.Error(), `widget w-1 not ready, last phase "Failed": no capacity`)
+}
+
+func TestWaiter_UnexpectedState(t *testing.T) {
+	w, _ := sequenceWaiter("Provisioning", "Stopped")
+	_, err := w.Wait(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unexpected state "Stopped"`)
+
+	// without a pending set every other state keeps the wait going
+	w, _ = sequenceWaiter("Provisioning", "Stopped", "Ready")
+	w.Pending = nil
+	_, err = w.Wait(context.Background())
+	require.NoError(t, err)
+}
+
+func TestWaiter_Check(t *testing.T) {
+	w, _ := sequenceWaiter("Provisioning")
+	w.Check = func(o *waitObj) error { return errors.New("listener failed") }
+	_, err := w.Wait(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "listener failed")
+}
+
+func TestWaiter_TargetNotFound(t *testing.T) {
+	w, _ := sequenceWaiter("Deleting", "gone")
+	w.Goal = "deleted"
+	w.Pending = nil
+	w.TargetNotFound = true
+	obj, err := w.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, obj)
+
+	// a create wait does not treat a missing resource as done
+	w, _ = sequenceWaiter("gone")
+	_, err = w.Wait(context.Background())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, common.ErrNotFound))
+}
+
+func TestWaiter_Deadline(t *testing.T) {
+	w, _ := sequenceWaiter("Provisioning")
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := w.Wait(ctx)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), `last phase "Provisioning"`)
+}
@@ -0,0 +1,192 @@
+package itacservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"terraform-provider-intelcloud/pkg/itacservices/common"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// DefaultWaitMinInterval is the first poll interval of a Waiter.
+	DefaultWaitMinInterval = 2 * time.Second
+	// DefaultWaitMaxInterval caps the poll interval of a Waiter as it doubles.
+	DefaultWaitMaxInterval = 30 * time.Second
+)
+
+// ErrWaitFailed is wrapped by the error of a wait that stopped because the resource
+// reached one of its failure states.
+var ErrWaitFailed = errors.New("resource failed")
+
+// Waiter polls a resource until it reaches one of the Target states. The wait fails when
+// the resource reaches a Failure state or a state outside Pending, or a read fails with an
+// error that is not retryable, and gives up when ctx is done. The poll interval starts at
+// MinInterval and doubles up to MaxInterval.
+type Waiter[T any] struct {
+	// Noun and ID name the resource in logs and errors.
+	Noun string
+	ID   string
+	// Goal describes the target states in errors, "ready" when empty.
+	Goal string
+
+	// Refresh reads the resource and returns it with its current state.
+	Refresh func(ctx context.Context) (*T, string, error)
+	// Message returns the status message the backend reports for the resource, if any.
+	Message func(*T) string
+	// Check reports failures the state does not show, such as a failed listener. It is
+	// only called while the resource has not reached a target state.
+	Check func(*T) error
+	// Done reports whether a resource in a target state shows the change the wait is for,
+	// such as a requested version, for changes the backend may not have started yet. Reads
+	// where it does not are pending.
+	Done func(*T) bool
+
+	// Pending lists the states to keep polling in. When empty, every state outside Target
+	// and Failure is pending.
+	Pending []string
+	Target  []string
+	Failure []string
+	// TargetNotFound counts a read failing with common.ErrNotFound as reaching a target
+	// state, for delete waits.
+	TargetNotFound bool
+
+	// MinTargetReads is how many consecutive reads must see a target state, at least 1.
+	MinTargetReads int
+	MinInterval    time.Duration
+	MaxInterval    time.Duration
+}
+
+// Wait polls until the resource reaches a target state and returns its last read, nil once
+// a delete wait no longer finds it.
+func (w *Waiter[T]) Wait(ctx context.Context) (*T, error) {
+	minInterval := w.MinInterval
+	if minInterval <= 0 {
+		minInterval = DefaultWaitMinInterval
+	}
+	maxInterval := w.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultWaitMaxInterval
+	}
+	maxInterval = max(maxInterval, minInterval)
+	minTargetReads := max(w.MinTargetReads, 1)
+	goal := w.Goal
+	if goal == "" {
+		goal = "ready"
+	}
+
+	var last *T
+	var state, message string
+	targetReads := 0
+	interval := minInterval
+	start := time.Now()
+
+	fail := func(err error) (*T, error) {
+		tflog.Info(ctx, "wait for "+w.Noun+" failed", map[string]any{"id": w.ID, "state": state, "elapsed": time.Since(start).String(), "error": err.Error()})
+		return last, waitError(w.Noun, w.ID, goal, state, message, err)
+	}
+	// sleep waits for the next poll, backing off up to maxInterval
+	sleep := func() error {
+		delay := pollDelay(ctx, interval, minInterval)
+		tflog.Debug(ctx, "polling "+w.Noun, map[string]any{"id": w.ID, "state": state, "next": delay.String()})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		interval = min(interval*2, maxInterval)
+		return nil
+	}
+
+	for reads := 1; ; reads++ {
+		obj, current, err := w.Refresh(ctx)
+		found := true
+		switch {
+		case err == nil:
+		case w.TargetNotFound && errors.Is(err, common.ErrNotFound):
+			found = false
+		case common.IsRetryable(err):
+			// a transient API error says nothing about the resource, read it again
+			tflog.Warn(ctx, "reading "+w.Noun+" failed, retrying", map[string]any{"id": w.ID, "error": err.Error()})
+			if err := sleep(); err != nil {
+				return fail(err)
+			}
+			continue
+		default:
+			return fail(err)
+		}
+
+		if found {
+			if reads == 1 || current != state {
+				tflog.Info(ctx, "waiting for "+w.Noun, map[string]any{"id": w.ID, "state": current, "elapsed": time.Since(start).String()})
+			}
+			last, state = obj, current
+			if w.Message != nil {
+				message = w.Message(obj)
+			}
+		}
+
+		inTarget := found && slices.Contains(w.Target, current)
+		switch {
+		case !found || inTarget && (w.Done == nil || w.Done(obj)):
+			targetReads++
+			if targetReads >= minTargetReads {
+				tflog.Info(ctx, w.Noun+" "+goal, map[string]any{"id": w.ID, "state": state, "reads": reads, "elapsed": time.Since(start).String()})
+				if !found {
+					return nil, nil
+				}
+				return last, nil
+			}
+			// confirm the target state without backing off
+			interval = minInterval
+		case slices.Contains(w.Failure, current):
+			return fail(ErrWaitFailed)
+		case inTarget:
+			targetReads = 0
+		default:
+			targetReads = 0
+			if w.Check != nil {
+				if err := w.Check(obj); err != nil {
+					return fail(err)
+				}
+			}
+			if len(w.Pending) > 0 && !slices.Contains(w.Pending, current) {
+				return fail(fmt.Errorf("unexpected state %q", current))
+			}
+		}
+
+		if err := sleep(); err != nil {
+			return fail(err)
+		}
+	}
+}
+
+// pollDelay shortens interval when the context deadline falls before the next poll, so the
+// last read happens before the deadline rather than sleeping through it.
+func pollDelay(ctx context.Context, interval, minInterval time.Duration) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return interval
+	}
+	remaining := time.Until(deadline)
+	if remaining < interval && remaining > minInterval {
+		return remaining - minInterval/2
+	}
+	return interval
+}
+
+// waitError reports a wait on the resource id that gave up, naming the last state read from
+// the backend and its status message so a failed provisioning says why.
+func waitError(noun, id, goal, state, message string, err error) error {
+	if message == "" {
+		return fmt.Errorf("%s %s not %s, last phase %q: %w", noun, id, goal, state, err)
+	}
+	return fmt.Errorf("%s %s not %s, last phase %q: %s: %w", noun, id, goal, state, message, err)
+}